@@ -0,0 +1,65 @@
+package axios
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// BasicAuth holds credentials for HTTP Basic authentication
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// applyAuth sets the Authorization header from the config unless one is already present
+func applyAuth(req *http.Request, config Config) {
+	if req.Header.Get("Authorization") != "" {
+		return
+	}
+
+	switch {
+	case config.BasicAuth != nil:
+		req.SetBasicAuth(config.BasicAuth.Username, config.BasicAuth.Password)
+	case config.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+config.BearerToken)
+	}
+}
+
+// BearerAuthInterceptor returns a reusable request interceptor that sets
+// "Authorization: Bearer <token>" on every outgoing request
+func BearerAuthInterceptor(token string) Interceptor {
+	return Interceptor{
+		Request: func(req *http.Request) (*http.Request, error) {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return req, nil
+		},
+	}
+}
+
+// NewOAuth2Interceptor returns an interceptor that attaches the token from tokenSource as a
+// bearer token and, on a 401 response, calls refresh and has the client replay the request once.
+// refresh is expected to make tokenSource return the new token. A replayed request that is
+// rejected again is not refreshed a second time, which prevents refresh loops, and a request
+// whose body cannot be sent again fails with ErrBodyNotReplayable after the refresh.
+func NewOAuth2Interceptor(tokenSource func(ctx context.Context) (string, error), refresh func(ctx context.Context) error) Interceptor {
+	return Interceptor{
+		Request: func(req *http.Request) (*http.Request, error) {
+			token, err := tokenSource(req.Context())
+			if err != nil {
+				return nil, fmt.Errorf("getting OAuth2 token: %w", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			return req, nil
+		},
+		Replay: func(ctx context.Context, resp *Response) (bool, error) {
+			if resp.StatusCode != http.StatusUnauthorized {
+				return false, nil
+			}
+			if err := refresh(ctx); err != nil {
+				return false, fmt.Errorf("refreshing OAuth2 token: %w", err)
+			}
+			return true, nil
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package axios
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Do sends the requests in parallel using at most concurrency workers (all at once when
+// concurrency <= 0) and returns their responses and errors in the order of configs.
+// A failed request does not stop the others; once ctx is done, requests that have not
+// started yet are skipped and report the context's error.
+func (c *Client) Do(ctx context.Context, configs []Config, concurrency int) ([]*Response, []error) {
+	responses := make([]*Response, len(configs))
+	errs := make([]error, len(configs))
+	if concurrency <= 0 || concurrency > len(configs) {
+		concurrency = len(configs)
+	}
+
+	// Workers pick up request indexes until the dispatcher stops handing them out
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				responses[i], errs[i] = c.Request(ctx, configs[i])
+			}
+		}()
+	}
+
+dispatch:
+	for i := range configs {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for ; i < len(configs); i++ {
+				errs[i] = fmt.Errorf("request not sent: %w", ctx.Err())
+			}
+			break dispatch
+		}
+	}
+	close(indexes)
+
+	wg.Wait()
+	return responses, errs
+}
@@ -0,0 +1,237 @@
+package axios
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// compressionThreshold is the smallest body Config.CompressRequest compresses; smaller
+// bodies would hardly shrink and are sent as-is
+const compressionThreshold = 1024
+
+// requestBody is an encoded request payload along with its default Content-Type
+type requestBody struct {
+	reader      io.Reader
+	contentType string
+	length      int64 // -1 when unknown, e.g. for streamed multipart bodies
+
+	contentEncoding string // Set when the body was compressed
+
+	// getBody returns a fresh copy of an in-memory body, letting net/http resend it
+	// when following 307/308 redirects and interceptors read it; nil for streamed bodies
+	getBody func() (io.ReadCloser, error)
+}
+
+// close releases the body if it was never fully consumed, e.g. a streaming multipart
+// pipe whose request failed before being sent
+func (b requestBody) close() {
+	if closer, ok := b.reader.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// bodyFieldCount returns how many of the mutually exclusive body fields are set
+func bodyFieldCount(config Config) int {
+	count := 0
+	for _, set := range []bool{
+		config.Body != nil,
+		config.JSONBody != nil,
+		config.MsgpackBody != nil,
+		config.ProtoBody != nil,
+		config.Form != nil,
+		config.Fields != nil || config.Files != nil,
+		config.BodyReader != nil,
+	} {
+		if set {
+			count++
+		}
+	}
+	return count
+}
+
+// prepareRequestBody prepares the request body based on the config
+func prepareRequestBody(config Config) (requestBody, error) {
+	body, err := encodeRequestBody(config)
+	if err != nil {
+		return requestBody{}, err
+	}
+
+	// Compress in-memory bodies large enough to benefit, unless already encoded
+	if config.CompressRequest && body.getBody != nil && body.length >= compressionThreshold &&
+		config.Headers.Get("Content-Encoding") == "" {
+		if body, err = gzipBody(body); err != nil {
+			return requestBody{}, err
+		}
+	}
+
+	// Report upload progress as the transport reads the body. Copies from getBody are
+	// wrapped later by reportReplayProgress, since interceptors may read them too.
+	if config.OnUploadProgress != nil && body.reader != nil {
+		body.reader = &progressReader{
+			reader:   body.reader,
+			total:    body.length,
+			progress: config.OnUploadProgress,
+		}
+	}
+	return body, nil
+}
+
+// reportReplayProgress makes bodies resent through req.GetBody on 307/308 redirects report
+// upload progress as well. It runs right before the request is sent, so reads of GetBody by
+// interceptors (e.g. to sign the body) and the debug dump are not reported as uploads.
+func reportReplayProgress(req *http.Request, total int64, progress func(bytesWritten, totalBytes int64)) {
+	getBody := req.GetBody
+	if getBody == nil || progress == nil {
+		return
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		reader, err := getBody()
+		if err != nil {
+			return nil, err
+		}
+		return &progressReader{reader: reader, total: total, progress: progress}, nil
+	}
+}
+
+// encodeRequestBody encodes whichever body field is set on the config
+func encodeRequestBody(config Config) (requestBody, error) {
+	if bodyFieldCount(config) > 1 {
+		return requestBody{}, ErrConflictingBody
+	}
+
+	switch {
+	case config.JSONBody != nil:
+		data, err := json.Marshal(config.JSONBody)
+		if err != nil {
+			return requestBody{}, fmt.Errorf("marshaling JSON body: %w", err)
+		}
+		return bytesBody(data, "application/json"), nil
+
+	case config.MsgpackBody != nil:
+		if config.MsgpackCodec == nil {
+			return requestBody{}, fmt.Errorf("marshaling MessagePack body: %w", ErrNoCodec)
+		}
+		data, err := config.MsgpackCodec.Marshal(config.MsgpackBody)
+		if err != nil {
+			return requestBody{}, fmt.Errorf("marshaling MessagePack body: %w", err)
+		}
+		return bytesBody(data, msgpackContentType), nil
+
+	case config.ProtoBody != nil:
+		if config.ProtoCodec == nil {
+			return requestBody{}, fmt.Errorf("marshaling protobuf body: %w", ErrNoCodec)
+		}
+		data, err := config.ProtoCodec.Marshal(config.ProtoBody)
+		if err != nil {
+			return requestBody{}, fmt.Errorf("marshaling protobuf body: %w", err)
+		}
+		return bytesBody(data, protoContentType), nil
+
+	case config.Form != nil:
+		return bytesBody([]byte(config.Form.Encode()), "application/x-www-form-urlencoded"), nil
+
+	case config.Fields != nil || config.Files != nil:
+		return newMultipartBody(config.Fields, config.Files), nil
+
+	case config.Body != nil:
+		return bytesBody(config.Body, ""), nil
+
+	case config.BodyReader != nil:
+		return readerBody(config.BodyReader, config.ContentLength)
+	}
+
+	return requestBody{}, nil
+}
+
+// bytesBody wraps an in-memory payload of known length that can be replayed
+func bytesBody(data []byte, contentType string) requestBody {
+	return requestBody{
+		reader:      bytes.NewReader(data),
+		contentType: contentType,
+		length:      int64(len(data)),
+		getBody: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		},
+	}
+}
+
+// gzipBody compresses an in-memory body, keeping its Content-Type
+func gzipBody(body requestBody) (requestBody, error) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := io.Copy(writer, body.reader); err != nil {
+		return requestBody{}, fmt.Errorf("compressing request body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return requestBody{}, fmt.Errorf("compressing request body: %w", err)
+	}
+
+	gzipped := bytesBody(compressed.Bytes(), body.contentType)
+	gzipped.contentEncoding = "gzip"
+	return gzipped, nil
+}
+
+// readerBody streams a caller-supplied reader, rewinding it first if it is an io.Seeker so
+// retries resend it from the start. The reader is hidden behind a wrapper so that net/http
+// never closes it; it stays owned by the caller.
+func readerBody(reader io.Reader, length int64) (requestBody, error) {
+	if seeker, ok := reader.(io.Seeker); ok {
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return requestBody{}, fmt.Errorf("rewinding body reader: %w", err)
+		}
+	}
+
+	if length <= 0 {
+		length = -1
+		if sized, ok := reader.(interface{ Len() int }); ok {
+			length = int64(sized.Len())
+		}
+	}
+	return requestBody{reader: struct{ io.Reader }{reader}, length: length}, nil
+}
+
+// replayable reports whether the config's body can be sent again on a retry: streamed
+// readers must be seekable so they can be rewound
+func replayable(config Config) bool {
+	if config.BodyReader != nil {
+		if _, ok := config.BodyReader.(io.Seeker); !ok {
+			return false
+		}
+	}
+	for _, file := range config.Files {
+		if _, ok := file.Reader.(io.Seeker); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// progressReader reports the running byte count after every read, for uploads and downloads
+type progressReader struct {
+	reader   io.Reader
+	read     int64
+	total    int64
+	progress func(bytesRead, totalBytes int64)
+}
+
+// Read reads from the underlying reader and reports progress
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.read += int64(n)
+		r.progress(r.read, r.total)
+	}
+	return n, err
+}
+
+// Close closes the underlying reader if it is closable
+func (r *progressReader) Close() error {
+	if closer, ok := r.reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
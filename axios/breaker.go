@@ -0,0 +1,194 @@
+package axios
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultFailureThreshold is used when CircuitBreakerConfig.FailureThreshold is not set
+	defaultFailureThreshold = 5
+
+	// defaultOpenDuration is used when CircuitBreakerConfig.OpenDuration is not set
+	defaultOpenDuration = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without sending the request while a host's circuit is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerConfig controls when requests to a failing host are short-circuited
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures that open a host's circuit (default 5)
+	OpenDuration     time.Duration // How long an open circuit fails fast before probing again (default 30s)
+
+	// HalfOpenProbes is how many trial requests may be in flight once OpenDuration has
+	// passed (default 1). A successful probe closes the circuit; a failed one reopens it.
+	HalfOpenProbes int
+
+	// IsFailure decides whether an attempt counts against the host. Defaults to
+	// DefaultCircuitFailure.
+	IsFailure func(*Response, error) bool
+}
+
+// DefaultCircuitFailure counts network errors, timeouts and 5xx status errors as failures
+func DefaultCircuitFailure(resp *Response, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.StatusCode >= 500
+	}
+	return !errors.Is(err, context.Canceled)
+}
+
+// circuitState is the state of a single host's circuit
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// hostCircuit tracks the failures of a single host
+type hostCircuit struct {
+	state    circuitState
+	failures int       // Consecutive failures while closed
+	openedAt time.Time // When the circuit last opened
+	probes   int       // Probes in flight while half-open
+}
+
+// circuitBreaker keeps a circuit per host
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+	mu     sync.Mutex
+	hosts  map[string]*hostCircuit
+}
+
+// SetCircuitBreaker makes the client fail fast with ErrCircuitOpen for hosts that keep
+// failing; nil removes the breaker. Circuits are keyed by the request URL's host and every
+// attempt, including retries, is counted. It must not be called concurrently with requests.
+func (c *Client) SetCircuitBreaker(config *CircuitBreakerConfig) {
+	if config == nil {
+		c.breaker = nil
+		return
+	}
+
+	cfg := *config
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.OpenDuration <= 0 {
+		cfg.OpenDuration = defaultOpenDuration
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = DefaultCircuitFailure
+	}
+	c.breaker = &circuitBreaker{config: cfg, hosts: make(map[string]*hostCircuit)}
+}
+
+// attempt sends a single attempt through the circuit breaker, if one is set
+func (c *Client) attempt(ctx context.Context, httpClient *http.Client, timeout time.Duration, finalConfig Config, requestURL string) (*Response, error) {
+	breaker := c.breaker
+	if breaker == nil {
+		return c.send(ctx, httpClient, timeout, finalConfig, requestURL)
+	}
+
+	host := requestHost(requestURL)
+	if err := breaker.allow(host); err != nil {
+		return nil, err
+	}
+
+	response, err := c.send(ctx, httpClient, timeout, finalConfig, requestURL)
+	if ctx.Err() != nil {
+		breaker.abandon(host) // The caller gave up; that says nothing about the host
+	} else {
+		breaker.record(host, breaker.config.IsFailure(response, err))
+	}
+	return response, err
+}
+
+// requestHost returns the host a request URL's circuit is keyed by
+func requestHost(requestURL string) string {
+	parsed, err := url.Parse(requestURL)
+	if err != nil {
+		return requestURL
+	}
+	return parsed.Host
+}
+
+// allow reports whether a request to host may be sent, moving an expired open circuit to half-open
+func (b *circuitBreaker) allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	circuit := b.hosts[host]
+	if circuit == nil {
+		circuit = &hostCircuit{}
+		b.hosts[host] = circuit
+	}
+
+	switch circuit.state {
+	case circuitOpen:
+		if time.Since(circuit.openedAt) < b.config.OpenDuration {
+			return fmt.Errorf("%w for %s", ErrCircuitOpen, host)
+		}
+		circuit.state = circuitHalfOpen
+		circuit.probes = 0
+		fallthrough
+	case circuitHalfOpen:
+		if circuit.probes >= b.config.HalfOpenProbes {
+			return fmt.Errorf("%w for %s", ErrCircuitOpen, host)
+		}
+		circuit.probes++
+	}
+	return nil
+}
+
+// record updates host's circuit with the outcome of an allowed request
+func (b *circuitBreaker) record(host string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	circuit := b.hosts[host]
+	switch circuit.state {
+	case circuitClosed:
+		if !failed {
+			circuit.failures = 0
+			return
+		}
+		circuit.failures++
+		if circuit.failures >= b.config.FailureThreshold {
+			circuit.state = circuitOpen
+			circuit.openedAt = time.Now()
+		}
+	case circuitHalfOpen:
+		if failed {
+			circuit.state = circuitOpen
+			circuit.openedAt = time.Now()
+		} else {
+			circuit.state = circuitClosed
+			circuit.failures = 0
+		}
+	}
+}
+
+// abandon frees a half-open probe slot without counting the request either way
+func (b *circuitBreaker) abandon(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if circuit := b.hosts[host]; circuit.state == circuitHalfOpen && circuit.probes > 0 {
+		circuit.probes--
+	}
+}
@@ -0,0 +1,341 @@
+package axios
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a stored response along with the time until which it may be served
+// without revalidation
+type CacheEntry struct {
+	Status     string
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+	Expires    time.Time // Zero or past entries are revalidated before use
+
+	// Vary holds the request headers named by the response's Vary header, as sent with the
+	// request that stored the entry; only requests with the same values are served it
+	Vary http.Header
+}
+
+// matches reports whether req sends the same values as the stored request for every
+// header the response varies on
+func (e *CacheEntry) matches(req *http.Request) bool {
+	for name, values := range e.Vary {
+		if !slices.Equal(req.Header.Values(name), values) {
+			return false
+		}
+	}
+	return true
+}
+
+// fresh reports whether the entry may be served without asking the server
+func (e *CacheEntry) fresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+// response rebuilds an HTTP response for req from the stored entry
+func (e *CacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        e.Status,
+		StatusCode:    e.StatusCode,
+		Header:        e.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// Cache stores responses keyed by request method and URL. Implementations must be safe
+// for concurrent use.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+}
+
+// maxCacheEntryBytes is the largest body stored in a cache; larger responses are passed
+// through without being cached, so caching never buffers a large download
+const maxCacheEntryBytes = 8 << 20
+
+// MemoryCacheOptions bounds a MemoryCache
+type MemoryCacheOptions struct {
+	MaxEntries int   // Defaults to 1000
+	MaxBytes   int64 // Total size of the stored bodies; defaults to 64MB
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used entries once it
+// holds more than its maximum number of entries or bytes
+type MemoryCache struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List // Front is the most recently used
+	size       int64
+	maxEntries int
+	maxBytes   int64
+}
+
+// memoryCacheItem is an entry along with the key it is stored under
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache creates an empty in-memory cache; opts may be nil
+func NewMemoryCache(opts *MemoryCacheOptions) *MemoryCache {
+	m := &MemoryCache{
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		maxEntries: 1000,
+		maxBytes:   64 << 20,
+	}
+	if opts != nil && opts.MaxEntries > 0 {
+		m.maxEntries = opts.MaxEntries
+	}
+	if opts != nil && opts.MaxBytes > 0 {
+		m.maxBytes = opts.MaxBytes
+	}
+	return m
+}
+
+// Get returns the entry stored under key, if any, marking it as recently used
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	return element.Value.(*memoryCacheItem).entry, true
+}
+
+// Set stores entry under key, replacing any previous entry and evicting the least
+// recently used ones beyond the limits. Entries larger than MaxBytes are not stored.
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if element, ok := m.entries[key]; ok {
+		m.remove(element)
+	}
+	if int64(len(entry.Body)) > m.maxBytes {
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	m.size += int64(len(entry.Body))
+	for m.order.Len() > m.maxEntries || m.size > m.maxBytes {
+		m.remove(m.order.Back())
+	}
+}
+
+// Len returns the number of stored entries
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// remove drops element from the cache; the caller must hold mu
+func (m *MemoryCache) remove(element *list.Element) {
+	item := m.order.Remove(element).(*memoryCacheItem)
+	delete(m.entries, item.key)
+	m.size -= int64(len(item.entry.Body))
+}
+
+// SetCache enables HTTP caching of GET responses; nil disables it. Fresh entries (per
+// Cache-Control max-age) are served without a request, stale ones are revalidated with
+// If-None-Match / If-Modified-Since and served again on 304 Not Modified. Responses
+// marked no-store or private, responses with Vary: * or bodies over 8MB, and streamed
+// requests or those copied to a ResponseWriter are never cached, and neither are requests carrying credentials (an Authorization header or
+// cookies), so a client shared between users never serves one user's response to
+// another. It must not be called concurrently with requests.
+func (c *Client) SetCache(cache Cache) {
+	c.cache = cache
+}
+
+// lookupCache returns the cache key for a cacheable request and any entry stored under it.
+// A stale entry's validators are added to the request unless conditional headers are already set.
+// A GET with a body, e.g. a search query, is never cached, since the key does not cover the body.
+// Neither is one with credentials, which jar would add cookies for; the response is private to them.
+func (c *Client) lookupCache(req *http.Request, config Config, jar http.CookieJar) (string, *CacheEntry) {
+	if c.cache == nil || config.Stream || config.ResponseWriter != nil || req.Method != http.MethodGet ||
+		(req.Body != nil && req.Body != http.NoBody) || hasCacheDirective(req.Header, "no-store") ||
+		hasCredentials(req, jar) {
+		return "", nil
+	}
+
+	key := req.Method + " " + req.URL.String()
+	entry, ok := c.cache.Get(key)
+	if !ok || !entry.matches(req) {
+		return key, nil // A stored response for other Vary values is replaced by this one's
+	}
+
+	if !entry.fresh() && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+		if etag := entry.Headers.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Headers.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+	return key, entry
+}
+
+// storeCache updates the cache with the response to a cacheable request. A 304 for a
+// stored entry is answered from the cache, which is reported as revalidated; a cacheable
+// 200 is buffered and stored.
+func (c *Client) storeCache(key string, entry *CacheEntry, resp *http.Response) (*http.Response, bool, error) {
+	if key == "" {
+		return resp, false, nil
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		// Refresh the stored headers and expiry with those sent alongside the 304
+		updated := *entry
+		updated.Headers = entry.Headers.Clone()
+		for name, values := range resp.Header {
+			if name != "Content-Length" {
+				updated.Headers[name] = values
+			}
+		}
+		updated.Expires = cacheExpiry(updated.Headers)
+		c.cache.Set(key, &updated)
+		return updated.response(resp.Request), true, nil
+
+	case resp.StatusCode == http.StatusOK && cacheable(resp.Header) && resp.ContentLength <= maxCacheEntryBytes:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxCacheEntryBytes+1))
+		if err != nil {
+			resp.Body.Close()
+			return nil, false, fmt.Errorf("reading response body: %w", err)
+		}
+		if int64(len(body)) > maxCacheEntryBytes {
+			// Too large to cache: hand back what was read followed by the rest of the body
+			resp.Body = &prefixedBody{ReadCloser: resp.Body, prefix: bytes.NewReader(body)}
+			return resp, false, nil
+		}
+		resp.Body.Close()
+
+		c.cache.Set(key, &CacheEntry{
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+			Body:       body,
+			Expires:    cacheExpiry(resp.Header),
+			Vary:       varyHeaders(resp),
+		})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, false, nil
+	}
+	return resp, false, nil
+}
+
+// hasCredentials reports whether req carries an Authorization header or cookies, whether
+// set on the request or added from jar when it is sent
+func hasCredentials(req *http.Request, jar http.CookieJar) bool {
+	if req.Header.Get("Authorization") != "" || req.Header.Get("Cookie") != "" {
+		return true
+	}
+	return jar != nil && len(jar.Cookies(req.URL)) > 0
+}
+
+// varyHeaders returns the request headers the response varies on, with the values they
+// were sent with
+func varyHeaders(resp *http.Response) http.Header {
+	if resp.Request == nil {
+		return nil
+	}
+	vary := make(http.Header)
+	for _, value := range resp.Header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				vary[http.CanonicalHeaderKey(name)] = resp.Request.Header.Values(name)
+			}
+		}
+	}
+	if len(vary) == 0 {
+		return nil
+	}
+	return vary
+}
+
+// prefixedBody reads prefix before the rest of the underlying body
+type prefixedBody struct {
+	io.ReadCloser
+	prefix *bytes.Reader
+}
+
+// Read reads the prefix first, then the underlying body
+func (b *prefixedBody) Read(p []byte) (int, error) {
+	if b.prefix.Len() > 0 {
+		return b.prefix.Read(p)
+	}
+	return b.ReadCloser.Read(p)
+}
+
+// cacheable reports whether a response may be stored: it must not be marked no-store or
+// private nor vary on everything, and must either be fresh for a while or carry a
+// validator to revalidate it with
+func cacheable(header http.Header) bool {
+	if hasCacheDirective(header, "no-store") || hasCacheDirective(header, "private") {
+		return false
+	}
+	for _, value := range header.Values("Vary") {
+		if strings.TrimSpace(value) == "*" {
+			return false
+		}
+	}
+	return header.Get("ETag") != "" || header.Get("Last-Modified") != "" || !cacheExpiry(header).IsZero()
+}
+
+// cacheExpiry returns until when a response may be served without revalidation, based on
+// Cache-Control max-age; the zero time means it must always be revalidated
+func cacheExpiry(header http.Header) time.Time {
+	if hasCacheDirective(header, "no-cache") {
+		return time.Time{}
+	}
+	for _, directive := range cacheDirectives(header) {
+		name, value, _ := strings.Cut(directive, "=")
+		if strings.EqualFold(name, "max-age") {
+			if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+				return time.Now().Add(time.Duration(seconds) * time.Second)
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// hasCacheDirective reports whether the Cache-Control header contains the directive
+func hasCacheDirective(header http.Header, directive string) bool {
+	for _, d := range cacheDirectives(header) {
+		if name, _, _ := strings.Cut(d, "="); strings.EqualFold(name, directive) {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheDirectives splits the Cache-Control header into its trimmed directives
+func cacheDirectives(header http.Header) []string {
+	var directives []string
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			if directive = strings.TrimSpace(directive); directive != "" {
+				directives = append(directives, directive)
+			}
+		}
+	}
+	return directives
+}
@@ -1,12 +1,20 @@
 package axios
 
 import (
-	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
-	"io"
+	"maps"
+	"net"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
 	"time"
+
+	"golang.org/x/net/http2"
 )
 
 // TransportOptions allows customization of http.Transport settings
@@ -16,6 +24,70 @@ type TransportOptions struct {
 	MaxIdleConnsPerHost int
 	TLSHandshakeTimeout time.Duration
 	ExpectContinue      time.Duration
+
+	// MaxConnsPerHost limits the total connections to a single host, including those in
+	// use; requests beyond it wait for a connection to free up. 0 means no limit.
+	MaxConnsPerHost int
+
+	// ResponseHeaderTimeout bounds the wait for a server's response headers once the
+	// request has been written, independently of the request timeout; 0 means no separate limit
+	ResponseHeaderTimeout time.Duration
+
+	// ReadBufferSize and WriteBufferSize size the buffers used when reading from and
+	// writing to connections; 0 uses Go's default of 4KB
+	ReadBufferSize  int
+	WriteBufferSize int
+
+	// DisableCompression stops the transport from requesting gzip responses. Responses
+	// are still decoded when Accept-Encoding is set explicitly.
+	DisableCompression bool
+
+	// DialTimeout bounds establishing a TCP connection, including DNS resolution,
+	// independently of the request timeout; 0 means no separate limit
+	DialTimeout time.Duration
+
+	// KeepAlive is the interval between TCP keep-alive probes on open connections;
+	// 0 uses the system default and a negative value disables them
+	KeepAlive time.Duration
+
+	// TLSConfig is an optional base TLS configuration for the transport
+	TLSConfig *tls.Config
+
+	// RootCAs overrides the certificate authorities used to verify servers, e.g. from LoadCertPool
+	RootCAs *x509.CertPool
+
+	// ClientCert is presented to servers requiring mutual TLS, e.g. from LoadClientCert
+	ClientCert *tls.Certificate
+
+	// Proxy selects the proxy for each request; ProxyURL is a simpler fixed alternative.
+	// When neither is set, HTTP_PROXY, HTTPS_PROXY and NO_PROXY from the environment are used.
+	Proxy    func(*http.Request) (*url.URL, error)
+	ProxyURL string
+
+	// InsecureSkipVerify disables server certificate verification. This exposes
+	// connections to man-in-the-middle attacks and must only be used in development.
+	InsecureSkipVerify bool
+
+	// ForceHTTP2 negotiates HTTP/2 over TLS even with a custom TLS configuration. Without
+	// it, HTTP/2 is only negotiated when no TLS options are set. Setting HTTP2 implies it.
+	ForceHTTP2 bool
+
+	// HTTP2 tunes HTTP/2 connections
+	HTTP2 *HTTP2Options
+}
+
+// HTTP2Options configures the HTTP/2 transport
+type HTTP2Options struct {
+	// ReadIdleTimeout sends a health-check ping on connections idle for this long;
+	// PingTimeout closes the connection if the ping is not answered in time (default 15s)
+	ReadIdleTimeout time.Duration
+	PingTimeout     time.Duration
+
+	// StrictMaxConcurrentStreams queues requests once a server's concurrent stream limit
+	// is reached instead of opening additional connections
+	StrictMaxConcurrentStreams bool
+
+	MaxReadFrameSize uint32 // Largest frame the client accepts; 0 uses the default of 16KB
 }
 
 // defaultTransport configures connection pooling and other transport settings
@@ -27,15 +99,69 @@ func defaultTransport(opts *TransportOptions) *http.Transport {
 			MaxIdleConnsPerHost: 100,
 			TLSHandshakeTimeout: 10 * time.Second,
 			ExpectContinue:      1 * time.Second,
+			DialTimeout:         30 * time.Second,
+			KeepAlive:           30 * time.Second,
 		}
 	}
 
-	return &http.Transport{
+	dialer := &net.Dialer{
+		Timeout:   opts.DialTimeout,
+		KeepAlive: opts.KeepAlive,
+	}
+
+	tlsConfig := buildTLSConfig(opts)
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
 		MaxIdleConns:          opts.MaxIdleConns,
 		IdleConnTimeout:       opts.IdleConnTimeout,
 		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       opts.MaxConnsPerHost,
+		ResponseHeaderTimeout: opts.ResponseHeaderTimeout,
+		ReadBufferSize:        opts.ReadBufferSize,
+		WriteBufferSize:       opts.WriteBufferSize,
+		DisableCompression:    opts.DisableCompression,
 		TLSHandshakeTimeout:   opts.TLSHandshakeTimeout,
 		ExpectContinueTimeout: opts.ExpectContinue,
+		TLSClientConfig:       tlsConfig,
+		Proxy:                 buildProxy(opts),
+		ForceAttemptHTTP2:     tlsConfig == nil, // The custom dialer would otherwise disable HTTP/2
+	}
+
+	if opts.ForceHTTP2 || opts.HTTP2 != nil {
+		configureHTTP2(transport, opts.HTTP2)
+	}
+	return transport
+}
+
+// configureHTTP2 enables HTTP/2 on the transport and applies the tuning options
+func configureHTTP2(transport *http.Transport, opts *HTTP2Options) {
+	// Only fails if the transport was already configured for HTTP/2, which a fresh one is not
+	h2, _ := http2.ConfigureTransports(transport)
+	if opts == nil {
+		return
+	}
+
+	h2.ReadIdleTimeout = opts.ReadIdleTimeout
+	h2.PingTimeout = opts.PingTimeout
+	h2.StrictMaxConcurrentStreams = opts.StrictMaxConcurrentStreams
+	h2.MaxReadFrameSize = opts.MaxReadFrameSize
+}
+
+// buildProxy returns the proxy selection function for the transport options
+func buildProxy(opts *TransportOptions) func(*http.Request) (*url.URL, error) {
+	switch {
+	case opts.Proxy != nil:
+		return opts.Proxy
+	case opts.ProxyURL != "":
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			err = fmt.Errorf("parsing proxy URL: %w", err)
+		}
+		return func(*http.Request) (*url.URL, error) {
+			return proxyURL, err
+		}
+	default:
+		return http.ProxyFromEnvironment
 	}
 }
 
@@ -44,53 +170,313 @@ type Client struct {
 	httpClient         *http.Client
 	config             Config
 	interceptorManager *InterceptorManager // Keep field unexported
+	rateLimiter        RateLimiter
+	semaphore          chan struct{} // Bounds in-flight requests when set
+	breaker            *circuitBreaker
+	cache              Cache
+	inflightMu         sync.Mutex
+	inflight           map[string]*inflightCall // Shares identical in-flight requests when Config.Dedupe is set
+	debug              *debugLogger
 }
 
-// NewClient creates a new Client with a custom timeout and optional transport settings
+// NewClient creates a new Client with a custom timeout and optional transport settings.
+// Cookies are persisted across requests in an in-memory cookie jar.
 func NewClient(config Config, transportOptions *TransportOptions) *Client {
+	jar, _ := cookiejar.New(nil) // Never fails without options
+
 	return &Client{
 		httpClient: &http.Client{
 			Transport: defaultTransport(transportOptions),
-			Timeout:   time.Duration(config.Timeout) * time.Second,
+			Timeout:   config.timeout(),
+			Jar:       jar,
 		},
 		config:             config,
 		interceptorManager: NewInterceptorManager(),
 	}
 }
 
+// NewClientWithHTTPClient creates a new Client on top of an existing http.Client, e.g. an
+// app-wide tuned client or one with an instrumented transport. The http.Client is used as-is,
+// including its timeout and cookie jar; interceptors and config still apply on top of it.
+func NewClientWithHTTPClient(config Config, hc *http.Client) *Client {
+	if hc == nil {
+		hc = &http.Client{}
+	}
+
+	return &Client{
+		httpClient:         hc,
+		config:             config,
+		interceptorManager: NewInterceptorManager(),
+	}
+}
+
+// Clone returns a copy of the client with its own config and interceptors, e.g. for
+// deriving per-tenant clients. The copy shares the transport and its connection pool, the
+// cookie jar, rate limiter, concurrency limit, circuit breaker, cache and debug output with the original.
+// Requests with credentials bypass the cache, so clones for different users can share it.
+func (c *Client) Clone() *Client {
+	hc := *c.httpClient // SetTransport and SetCookieJar on the copy leave the original alone
+
+	clone := &Client{
+		httpClient:  &hc,
+		config:      c.config,
+		rateLimiter: c.rateLimiter,
+		semaphore:   c.semaphore,
+		breaker:     c.breaker,
+		cache:       c.cache,
+		debug:       c.debug,
+	}
+	clone.config.Headers = c.config.Headers.Clone()
+	clone.config.Params = maps.Clone(c.config.Params)
+	if c.interceptorManager != nil {
+		clone.interceptorManager = c.interceptorManager.clone()
+	} else {
+		clone.interceptorManager = NewInterceptorManager()
+	}
+	return clone
+}
+
+// With returns a Clone whose default config is overridden by config, the same way a
+// per-request config overrides the defaults. A timeout in config becomes the clone's
+// client-wide timeout.
+func (c *Client) With(config Config) *Client {
+	clone := c.Clone()
+	clone.config = mergeConfig(clone.config, config)
+	if timeout := config.timeout(); timeout > 0 {
+		clone.httpClient.Timeout = timeout
+	}
+	return clone
+}
+
+// SetTransport replaces the http.RoundTripper used to send requests, e.g. to wrap it with
+// instrumentation or to mock responses. It must not be called concurrently with requests.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// Close releases the client's idle keep-alive connections, e.g. on shutdown. In-flight
+// requests are not aborted; they end according to their own context. The client stays
+// usable afterwards and opens new connections as needed. Clones share the transport, so
+// closing one closes the idle connections of all. It is safe to call concurrently.
+func (c *Client) Close() {
+	c.httpClient.CloseIdleConnections()
+}
+
 // GetInterceptorManager returns the interceptor manager for the client
 func (c *Client) GetInterceptorManager() *InterceptorManager {
 	return c.interceptorManager
 }
 
+// SetCookieJar replaces the cookie jar used to store and send cookies; nil disables cookies.
+// It must not be called concurrently with requests.
+func (c *Client) SetCookieJar(jar http.CookieJar) {
+	c.httpClient.Jar = jar
+}
+
 // HTTPClient returns the internal http.Client (used for testing purposes)
 func (c *Client) HTTPClient() *http.Client {
 	return c.httpClient
 }
 
-// prepareRequestBody prepares the request body based on the config
-func prepareRequestBody(config Config) (io.Reader, error) {
-	if config.Body == nil {
-		return nil, nil
+// Request sends an HTTP request and returns the parsed response.
+// A timeout set on config applies to each attempt and overrides the client's default timeout.
+// Response interceptors run for every response, including error statuses, so they can
+// recover from them; a status still rejected by Config.ValidateStatus afterwards is
+// returned as a *RequestError carrying the Response. An interceptor's Replay hook can have
+// the request sent again, e.g. after refreshing credentials.
+// Any error is finally passed through the error interceptors.
+func (c *Client) Request(ctx context.Context, config Config) (*Response, error) {
+	finalConfig := mergeConfig(c.config, config)
+	finalConfig.Method = requestMethod(finalConfig.Method)
+	if err := finalConfig.Validate(); err != nil {
+		return nil, c.interceptError(ctx, err)
+	}
+
+	requestURL, err := finalURL(finalConfig)
+	if err != nil {
+		return nil, c.interceptError(ctx, err)
+	}
+
+	// Fix the idempotency key once so every retry sends the same one
+	finalConfig.IdempotencyKey = idempotencyKey(finalConfig)
+
+	// A per-request timeout replaces the client-wide one for this call
+	timeout := config.timeout()
+	httpClient := c.httpClientFor(timeout, redirectPolicy(finalConfig))
+
+	// Send the request, sharing the outcome with identical in-flight requests if asked to.
+	// Request interceptors may add per-call headers or credentials the key cannot see, and
+	// requests skipping interceptors see different responses, so neither is ever shared.
+	var response *Response
+	if key, ok := dedupeKey(finalConfig, requestURL); ok && ctx.Value(skipInterceptorsKey{}) == nil &&
+		!c.interceptorManager.hasRequestInterceptors() {
+		response, err = c.dedupe(ctx, key, func(ctx context.Context) (*Response, error) {
+			return c.sendWithRetry(ctx, httpClient, timeout, finalConfig, requestURL)
+		})
+	} else {
+		response, err = c.sendWithRetry(ctx, httpClient, timeout, finalConfig, requestURL)
+	}
+
+	if err == nil {
+		err = decodeResult(response, finalConfig.Result)
+	}
+	if err != nil {
+		return response, c.interceptError(ctx, err)
+	}
+	return response, nil
+}
+
+// finalURL fills in the path params, resolves the URL against the base URL, encodes the
+// query params onto it and checks that the result is a usable absolute URL
+func finalURL(config Config) (string, error) {
+	requestURL, err := expandPath(config.URL, config.PathParams)
+	if err != nil {
+		return "", fmt.Errorf("expanding path parameters: %w", err)
+	}
+	requestURL, err = resolveURL(config.BaseURL, requestURL)
+	if err != nil {
+		return "", fmt.Errorf("resolving request URL: %w", err)
+	}
+	requestURL, err = buildURL(requestURL, config.Params)
+	if err != nil {
+		return "", fmt.Errorf("building request URL: %w", err)
 	}
-	return bytes.NewBuffer(config.Body), nil
+	if err := validateURL(requestURL); err != nil {
+		return "", err
+	}
+	return requestURL, nil
 }
 
-// Request sends an HTTP request and returns the parsed response
-func (c *Client) Request(ctx context.Context, config Config) (*Response, error) {
-	finalConfig := mergeConfig(c.config, config)
+// sendWithRetry sends the request once, or keeps retrying according to the retry policy
+func (c *Client) sendWithRetry(ctx context.Context, httpClient *http.Client, timeout time.Duration, finalConfig Config, requestURL string) (*Response, error) {
+	retry := finalConfig.Retry
+	replayed := make(map[int]bool) // Interceptors whose Replay hook already resent this request
+	for attempt := 0; ; {
+		response, err := c.attempt(ctx, httpClient, timeout, finalConfig, requestURL)
+		replay, replayErr := c.shouldReplay(ctx, finalConfig, response, err, replayed)
+		if replayErr != nil {
+			return response, replayErr
+		}
+		if replay {
+			if response != nil && response.RawBody != nil {
+				response.RawBody.Close()
+			}
+			continue // A replay does not use up a retry
+		}
+
+		if retry == nil || attempt >= retry.MaxRetries || !replayable(finalConfig) || !retry.shouldRetry(ctx, finalConfig, response, err) {
+			return response, err
+		}
+
+		if waitErr := sleepContext(ctx, retry.delay(attempt, response, err)); waitErr != nil {
+			return nil, fmt.Errorf("waiting to retry: %w", waitErr)
+		}
+		attempt++
+	}
+}
+
+// shouldReplay asks the interceptors' Replay hooks whether to resend the request right away
+// after an attempt that got a response, accepted or rejected by Config.ValidateStatus.
+// A request whose body cannot be sent again fails with ErrBodyNotReplayable instead.
+func (c *Client) shouldReplay(ctx context.Context, finalConfig Config, response *Response, err error, replayed map[int]bool) (bool, error) {
+	if c.interceptorManager == nil {
+		return false, nil
+	}
+
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		response = reqErr.Response
+	}
+	if response == nil {
+		return false, nil
+	}
+
+	replay, replayErr := c.interceptorManager.replay(ctx, response, replayed)
+	if replayErr != nil {
+		return false, replayErr
+	}
+	if replay && !replayable(finalConfig) {
+		return false, fmt.Errorf("replaying request after status %d: %w", response.StatusCode, ErrBodyNotReplayable)
+	}
+	return replay, nil
+}
 
-	// Prepare the request body
-	body, err := prepareRequestBody(finalConfig)
+// interceptError runs the error interceptors on a failed request's error
+func (c *Client) interceptError(ctx context.Context, err error) error {
+	if c.interceptorManager == nil {
+		return err
+	}
+	return c.interceptorManager.applyErrorInterceptors(ctx, err)
+}
+
+// httpClientFor returns the http.Client to use for a request. When the request overrides
+// the timeout or redirect policy, a copy sharing the transport and cookie jar is returned;
+// a positive timeout drops the client-wide timeout so a per-request context deadline governs.
+func (c *Client) httpClientFor(timeout time.Duration, checkRedirect func(*http.Request, []*http.Request) error) *http.Client {
+	if timeout <= 0 && checkRedirect == nil {
+		return c.httpClient
+	}
+
+	hc := *c.httpClient
+	if timeout > 0 {
+		hc.Timeout = 0
+	}
+	if checkRedirect != nil {
+		hc.CheckRedirect = checkRedirect
+	}
+	return &hc
+}
+
+// send performs a single HTTP round trip for the resolved request URL.
+// The body is rebuilt from the config on every call so retries can resend it.
+// A positive timeout bounds this attempt; an earlier deadline on ctx still wins.
+func (c *Client) send(ctx context.Context, httpClient *http.Client, timeout time.Duration, finalConfig Config, requestURL string) (*Response, error) {
+	// Throttle and wait for a concurrency slot before the attempt's timeout starts counting
+	if err := c.waitRateLimit(ctx); err != nil {
+		return nil, err
+	}
+	releaseSlot, err := c.acquireSlot(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("preparing request body: %w", err)
+		return nil, err
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
 	}
 
+	// release frees the attempt's resources; a streamed response takes it over until closed
+	release := func() {
+		cancel()
+		releaseSlot()
+	}
+	defer func() {
+		if release != nil {
+			release()
+		}
+	}()
+
 	// Create a new request with context (supports timeout and cancellation)
-	req, err := http.NewRequestWithContext(ctx, finalConfig.Method, finalConfig.URL, body)
+	ctx = withValues(ctx, finalConfig.Values)
+	var trace *tracer
+	if finalConfig.Trace {
+		ctx, trace = withTrace(ctx)
+	}
+	var redirects *redirectHistory
+	if finalConfig.TrackRedirects {
+		ctx, redirects = withRedirectHistory(ctx, finalConfig)
+	}
+	req, body, err := newRequest(ctx, finalConfig, requestURL)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, err
 	}
+	defer body.close()
+	if finalConfig.DisableKeepAlive {
+		req.Close = true // Sends Connection: close and discards the connection afterwards
+	}
+
+	// Config headers go first, so interceptors see them and a header they set replaces the config's
+	applyConfigHeaders(req, finalConfig)
 
 	// Apply request interceptors if any exist
 	if c.interceptorManager != nil {
@@ -100,29 +486,219 @@ func (c *Client) Request(ctx context.Context, config Config) (*Response, error)
 		}
 	}
 
-	// Set headers from config without overwriting existing ones
-	for key, values := range finalConfig.Headers {
-		for _, value := range values {
-			req.Header.Add(key, value)
+	applyHeaders(req, finalConfig, body)
+
+	// Execute the HTTP request, unless a fresh cached response can be served
+	startedAt := time.Now()
+	cacheKey, cached := c.lookupCache(req, finalConfig, httpClient.Jar)
+	var resp *http.Response
+	fromCache := cached != nil && cached.fresh()
+	revalidated := false
+	if fromCache {
+		resp = cached.response(req)
+	} else {
+		if c.debug != nil {
+			c.debug.dumpRequest(req)
+		}
+		reportReplayProgress(req, body.length, finalConfig.OnUploadProgress)
+		resp, err = httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("executing request: %w", err)
+		}
+		if finalConfig.BodyReadTimeout > 0 && !finalConfig.Stream {
+			limitBodyReadTime(resp, finalConfig.BodyReadTimeout) // Innermost, so closing it aborts the read
+		}
+		decompressBody(resp) // Before the size limit, which applies to the decompressed body
+		if finalConfig.MaxResponseBytes > 0 {
+			if err := limitBody(resp, finalConfig.MaxResponseBytes); err != nil {
+				return nil, err
+			}
+		}
+		resp, revalidated, err = c.storeCache(cacheKey, cached, resp)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	// Execute the HTTP request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+	// Report download progress as the body is read, whether buffered or streamed
+	if finalConfig.OnDownloadProgress != nil {
+		resp.Body = &progressReader{
+			reader:   resp.Body,
+			total:    resp.ContentLength,
+			progress: finalConfig.OnDownloadProgress,
+		}
+	}
+
+	// Parse the response, or hand the open body to the caller when streaming.
+	// Error responses are always read so their body can be reported.
+	var response *Response
+	written := finalConfig.ResponseWriter != nil && finalConfig.validStatus(resp.StatusCode)
+	switch {
+	case finalConfig.Stream && finalConfig.validStatus(resp.StatusCode):
+		response = newStreamResponse(ctx, resp, release)
+		release = nil // The stream's Close now releases the timeout context and concurrency slot
+	case written:
+		response, err = writeResponse(resp, finalConfig.ResponseWriter)
+		if err != nil {
+			return nil, err
+		}
+		releaseSlot()
+	default:
+		response, err = ParseResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		releaseSlot() // The body is read; interceptors below may issue requests of their own
+	}
+	if c.debug != nil && !fromCache {
+		c.debug.dumpResponse(resp, response.Body, response.RawBody != nil || written)
+	}
+	response.Config = finalConfig
+	response.Request = req
+	response.FromCache = fromCache || revalidated
+	response.Revalidated = revalidated
+	response.StartedAt = startedAt
+	response.Duration = time.Since(startedAt)
+	if trace != nil {
+		response.Timings = trace.snapshot()
+	}
+	if redirects != nil {
+		response.Redirects = redirects.list()
+	}
+
+	// Apply response interceptors if any exist
+	if c.interceptorManager != nil {
+		original := response
+		response, err = c.interceptorManager.ApplyResponseInterceptors(response)
+		if err != nil {
+			if original.RawBody != nil {
+				original.RawBody.Close() // Nobody else holds the stream, so free its connection and slot
+			}
+			return nil, fmt.Errorf("applying response interceptors: %w", err)
+		}
+	}
+
+	// Check for HTTP errors (status code >= 400 unless ValidateStatus says otherwise) left after interception
+	if !finalConfig.validStatus(response.StatusCode) {
+		if response.RawBody != nil {
+			response.RawBody.Close()
+		}
+		return nil, newRequestError(resp, response)
 	}
 
-	// Check for HTTP errors (status code >= 400)
-	if resp.StatusCode >= 400 {
-		return nil, HandleResponseError(resp)
+	return response, nil
+}
+
+// decodeResult unmarshals a successful JSON response into result, if one was requested.
+// Empty bodies (e.g. 204 No Content) leave result untouched.
+func decodeResult(response *Response, result interface{}) error {
+	if result == nil || !response.IsSuccess() || len(response.Body) == 0 {
+		return nil
 	}
+	if err := response.ParseJSON(result); err != nil {
+		return &DecodeError{Err: err}
+	}
+	return nil
+}
+
+// RequestJSON sends the request and decodes a successful JSON response into a value of type T.
+// The raw Response is returned alongside for status and header access. Decode failures are
+// reported as a *DecodeError so they can be told apart from transport and status errors.
+func RequestJSON[T any](ctx context.Context, c *Client, config Config) (T, *Response, error) {
+	var result T
+	config.Result = &result
 
-	// Parse and return the response
-	return ParseResponse(resp)
+	response, err := c.Request(ctx, config)
+	return result, response, err
+}
+
+// RequestStream sends the request in streaming mode: the returned Response carries the
+// open body in RawBody instead of a pre-read Body. The caller must close RawBody; it is
+// also closed once ctx is done.
+func (c *Client) RequestStream(ctx context.Context, config Config) (*Response, error) {
+	config.Stream = true
+	return c.Request(ctx, config)
 }
 
 // CancelableRequest sends an HTTP request that supports cancellation via context
 func (c *Client) CancelableRequest(ctx context.Context, config Config) (*Response, error) {
 	return c.Request(ctx, config)
 }
+
+// newRequest creates the request for an attempt along with its prepared body, or lets
+// Config.RequestBuilder create it
+func newRequest(ctx context.Context, config Config, requestURL string) (*http.Request, requestBody, error) {
+	if config.RequestBuilder != nil {
+		built := config
+		built.URL, built.BaseURL, built.Params, built.PathParams = requestURL, "", nil, nil
+		req, err := config.RequestBuilder(ctx, built)
+		if err != nil {
+			return nil, requestBody{}, fmt.Errorf("building request: %w", err)
+		}
+		if req == nil {
+			return nil, requestBody{}, errors.New("building request: RequestBuilder returned no request")
+		}
+		if req.Context() != ctx {
+			req = req.WithContext(ctx) // Keep the timeout, cancellation and tracing
+		}
+		if req.Header == nil {
+			req.Header = http.Header{}
+		}
+		return req, requestBody{}, nil
+	}
+
+	body, err := prepareRequestBody(config)
+	if err != nil {
+		return nil, requestBody{}, fmt.Errorf("preparing request body: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, config.Method, requestURL, body.reader)
+	if err != nil {
+		body.close()
+		return nil, requestBody{}, fmt.Errorf("creating request: %w", err)
+	}
+	if body.length > 0 {
+		req.ContentLength = body.length // Wrapped readers hide the length from net/http
+	}
+	if body.getBody != nil {
+		req.GetBody = body.getBody // Lets 307/308 redirects resend the body and interceptors read it
+	}
+	return req, body, nil
+}
+
+// applyConfigHeaders adds the headers from config to req, before request interceptors run
+func applyConfigHeaders(req *http.Request, config Config) {
+	for key, values := range config.Headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+}
+
+// applyHeaders sets the User-Agent, Idempotency-Key, credentials and body headers from
+// config on req, after request interceptors run, unless a header is already present
+func applyHeaders(req *http.Request, config Config, body requestBody) {
+	// Identify the client unless a User-Agent header was set explicitly
+	if req.Header.Get("User-Agent") == "" {
+		userAgent := config.UserAgent
+		if userAgent == "" {
+			userAgent = DefaultUserAgent
+		}
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	// Identify retried attempts as the same operation
+	if config.IdempotencyKey != "" && req.Header.Get("Idempotency-Key") == "" {
+		req.Header.Set("Idempotency-Key", config.IdempotencyKey)
+	}
+
+	// Apply credentials without clobbering an explicit Authorization header
+	applyAuth(req, config)
+
+	// Default the Content-Type for encoded bodies unless one was set explicitly
+	if body.contentType != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", body.contentType)
+	}
+	if body.contentEncoding != "" {
+		req.Header.Set("Content-Encoding", body.contentEncoding)
+	}
+}
@@ -0,0 +1,64 @@
+package axios
+
+import (
+	"errors"
+	"mime"
+)
+
+// msgpackContentType is the Content-Type set for Config.MsgpackBody
+const msgpackContentType = "application/msgpack"
+
+// protoContentType is the Content-Type set for Config.ProtoBody
+const protoContentType = "application/x-protobuf"
+
+// ErrNoCodec is returned when encoding or decoding a format whose codec is not configured
+var ErrNoCodec = errors.New("no codec configured")
+
+// Codec marshals and unmarshals a serialization format that go-axios does not implement
+// itself, such as MessagePack or protobuf, so that the library providing it stays an opt-in dependency
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// CodecFuncs adapts a pair of functions, such as a library's Marshal and Unmarshal, to a Codec
+type CodecFuncs struct {
+	MarshalFunc   func(v interface{}) ([]byte, error)
+	UnmarshalFunc func(data []byte, v interface{}) error
+}
+
+// Marshal calls MarshalFunc
+func (f CodecFuncs) Marshal(v interface{}) ([]byte, error) {
+	return f.MarshalFunc(v)
+}
+
+// Unmarshal calls UnmarshalFunc
+func (f CodecFuncs) Unmarshal(data []byte, v interface{}) error {
+	return f.UnmarshalFunc(data, v)
+}
+
+// isMsgpack reports whether contentType is one of the media types used for MessagePack
+func isMsgpack(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "application/msgpack", "application/x-msgpack", "application/vnd.msgpack":
+		return true
+	}
+	return false
+}
+
+// isProtobuf reports whether contentType is one of the media types used for protobuf
+func isProtobuf(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "application/x-protobuf", "application/protobuf", "application/vnd.google.protobuf":
+		return true
+	}
+	return false
+}
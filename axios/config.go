@@ -1,15 +1,231 @@
 package axios
 
-import "net/http"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpguts"
+)
 
 // Config stores the HTTP request configuration options
 type Config struct {
-	Method  string
-	URL     string
-	Headers http.Header
-	Params  map[string]string
-	Body    []byte
+	Method      string // Uppercased; defaults to GET
+	BaseURL     string // Prepended to URL when URL is relative
+	URL         string
+	Headers     http.Header
+	UserAgent   string     // Optional: Overrides DefaultUserAgent; a User-Agent header still wins
+	BasicAuth   *BasicAuth // Optional: Sets HTTP Basic auth unless Headers has an Authorization header
+	BearerToken string     // Optional: Sets a Bearer Authorization header, ignored if BasicAuth is set
+	Params      map[string]string
+	PathParams  map[string]string // Values for {name} placeholders in URL, escaped as path segments
+	Body        []byte            // Sent with any method, including GET and DELETE, like the other body fields
+	JSONBody    interface{}       // Marshaled to JSON and sent as the body; mutually exclusive with Body
+	Form        url.Values        // Sent URL-encoded as the body; mutually exclusive with Body and JSONBody
+	Fields      map[string]string // Multipart form fields; together with Files sent as multipart/form-data
+	Files       []FormFile        // Multipart file parts, streamed rather than buffered
+	Result      interface{}       // Optional: Pointer that a 2xx JSON response is decoded into
+	Stream      bool              // Leave the response body open in Response.RawBody instead of reading it
+
+	// ResponseWriter receives the body of a successful response as it is read, instead of
+	// buffering it in Response.Body, e.g. to save it to a file or proxy it; Stream takes
+	// precedence. Error responses are still buffered so they can be reported. A request
+	// whose body fails partway through is not retried, since part of it may already have
+	// been written.
+	ResponseWriter io.Writer
+
+	// MsgpackBody is marshaled with MsgpackCodec and sent as the body with Content-Type
+	// application/msgpack; mutually exclusive with the other body fields
+	MsgpackBody interface{}
+
+	// MsgpackCodec encodes MsgpackBody and decodes Response.ParseMsgpack, keeping the
+	// MessagePack library an opt-in dependency. It is usually set once on the client's
+	// defaults, e.g. to CodecFuncs{MarshalFunc: msgpack.Marshal, UnmarshalFunc: msgpack.Unmarshal}.
+	MsgpackCodec Codec
+
+	// ProtoBody, a protobuf message, is marshaled with ProtoCodec and sent as the body with
+	// Content-Type application/x-protobuf; mutually exclusive with the other body fields
+	ProtoBody interface{}
+
+	// ProtoCodec encodes ProtoBody and decodes Response.ParseProto, keeping the protobuf
+	// runtime an opt-in dependency. Set it to protoaxios.Codec{} from the separate
+	// github.com/MOHAMMADmiZAN/go-axios/protoaxios module, usually on the client's defaults.
+	ProtoCodec Codec
+
+	// BodyReader is streamed as the body without buffering it; mutually exclusive with the
+	// other body fields. It is rewound before each attempt if it is an io.Seeker, otherwise
+	// the request is not retried. Since it cannot be replayed mid-request, a 307/308
+	// redirect is returned as-is instead of being followed. The client never closes it.
+	BodyReader io.Reader
+
+	// ContentLength is the length of BodyReader, if known; otherwise the body is sent
+	// chunked unless the reader reports its size with a Len method
+	ContentLength int64
+
+	// CompressRequest gzips in-memory request bodies of 1KB or more and sets
+	// Content-Encoding: gzip, unless Headers already set a Content-Encoding
+	CompressRequest bool
+
+	// BodyReadTimeout bounds the time spent reading the response body once the headers
+	// have arrived, e.g. against servers trickling out a body; 0 means no separate limit.
+	// It fails with ErrBodyReadTimeout and does not apply to streamed responses.
+	BodyReadTimeout time.Duration
+
+	// MaxResponseBytes caps the response body size; larger bodies fail with
+	// ErrResponseTooLarge. 0 means unlimited.
+	MaxResponseBytes int64
+
+	// OnUploadProgress is called as the request body is sent, including copies resent on
+	// 307/308 redirects but not reads by interceptors; totalBytes is -1 when unknown
+	OnUploadProgress func(bytesWritten, totalBytes int64)
+
+	// OnDownloadProgress is called as the response body is read; totalBytes is -1 when unknown
+	OnDownloadProgress func(bytesRead, totalBytes int64)
+
+	// Timeout is the request timeout in whole seconds.
+	//
+	// Deprecated: Use RequestTimeout, which takes precedence when set.
 	Timeout int
+
+	RequestTimeout time.Duration // Request timeout, e.g. 500 * time.Millisecond
+	Retry          *RetryConfig  // Optional: Retry failed requests, nil disables retries
+
+	// IdempotencyKey is sent as the Idempotency-Key header, identical on every retry, so
+	// servers that support it process a retried POST or PATCH only once. With
+	// AutoIdempotencyKey set, a random key is generated per call for POST and PATCH
+	// requests that have none.
+	IdempotencyKey     string
+	AutoIdempotencyKey bool
+
+	// Values carries per-request metadata (e.g. a tenant ID or a flag to skip auth)
+	// that interceptors can read with RequestValue
+	Values map[string]interface{}
+
+	// MaxRedirects caps the redirect chain; 0 keeps Go's default of 10 and a negative
+	// value disables redirects so the 3xx response is returned as-is
+	MaxRedirects int
+
+	// ValidateStatus reports whether a status code is a success; other statuses are returned
+	// as a *RequestError. Defaults to accepting any status below 400.
+	ValidateStatus func(statusCode int) bool
+
+	// NoStatusError returns the Response for any status instead of a *RequestError, so
+	// error bodies can be inspected like any other; it overrides ValidateStatus. Since no
+	// status error is produced, retries and the circuit breaker only react to network
+	// errors unless their RetryOn or failure policy checks the response.
+	NoStatusError bool
+
+	// FollowRedirects set to false returns 3xx responses as-is instead of following them.
+	// Nil follows redirects; use Bool(false) to disable.
+	FollowRedirects *bool
+
+	// CheckRedirect is an optional http.Client.CheckRedirect policy applied to this request
+	CheckRedirect func(req *http.Request, via []*http.Request) error
+
+	// TrackRedirects records the redirects followed in Response.Redirects, up to
+	// MaxRedirects (or 10) hops
+	TrackRedirects bool
+
+	// DisableKeepAlive sends this request on a connection that is closed afterwards
+	// instead of returned to the pool, e.g. for servers that misbehave on reused
+	// connections. Pooling stays enabled for other requests.
+	DisableKeepAlive bool
+
+	// Trace records DNS, connect, TLS and time-to-first-byte timings in Response.Timings
+	Trace bool
+
+	// Dedupe shares one request between concurrent identical GET and HEAD requests (same
+	// URL, headers and credentials); each caller gets its own copy of the response and stops
+	// waiting when its own context is done. Requests with Values, a RequestBuilder or a
+	// ValidateStatus func, and clients with request interceptors, are never shared.
+	Dedupe bool
+
+	// RequestBuilder, when set, replaces the default construction of the *http.Request,
+	// e.g. to set Host or Trailer. It is called for every attempt with a config whose URL
+	// is already resolved against BaseURL, PathParams and Params, and is responsible for
+	// the body; the body fields are ignored. Headers, credentials and interceptors are
+	// still applied to the request it returns, which always runs with ctx.
+	RequestBuilder func(ctx context.Context, config Config) (*http.Request, error)
+}
+
+// requestMethod normalizes a configured method to uppercase, defaulting to GET when empty
+func requestMethod(method string) string {
+	if method == "" {
+		return http.MethodGet
+	}
+	return strings.ToUpper(method)
+}
+
+// Bool returns a pointer to v, for optional boolean Config fields such as FollowRedirects
+func Bool(v bool) *bool {
+	return &v
+}
+
+// validStatus reports whether the status code should be treated as a success
+func (c Config) validStatus(statusCode int) bool {
+	if c.NoStatusError {
+		return true
+	}
+	if c.ValidateStatus != nil {
+		return c.ValidateStatus(statusCode)
+	}
+	return statusCode < 400
+}
+
+// Validate checks the config for common mistakes before anything is sent: a missing or
+// malformed URL, an invalid method, negative timeouts or limits, conflicting body fields
+// and a MessagePack body without a codec. Every problem found is reported in one error
+// matching ErrInvalidConfig as well as the specific error, such as ErrInvalidURL or
+// ErrConflictingBody. Request validates the config merged with the client's defaults.
+func (c Config) Validate() error {
+	var problems []error
+	if _, err := finalURL(c); err != nil {
+		problems = append(problems, err)
+	}
+	if method := requestMethod(c.Method); strings.IndexFunc(method, func(r rune) bool { return !httpguts.IsTokenRune(r) }) >= 0 {
+		problems = append(problems, fmt.Errorf("invalid method %q", c.Method))
+	}
+	if c.Timeout < 0 || c.RequestTimeout < 0 || c.BodyReadTimeout < 0 {
+		problems = append(problems, errors.New("negative timeout"))
+	}
+	if c.MaxResponseBytes < 0 {
+		problems = append(problems, errors.New("negative MaxResponseBytes"))
+	}
+	if bodyFieldCount(c) > 1 {
+		problems = append(problems, ErrConflictingBody)
+	}
+	if c.MsgpackBody != nil && c.MsgpackCodec == nil {
+		problems = append(problems, fmt.Errorf("MsgpackBody without MsgpackCodec: %w", ErrNoCodec))
+	}
+	if c.ProtoBody != nil && c.ProtoCodec == nil {
+		problems = append(problems, fmt.Errorf("ProtoBody without ProtoCodec: %w", ErrNoCodec))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	// One %w per problem keeps each of them matchable with errors.Is
+	args := []interface{}{ErrInvalidConfig}
+	for _, problem := range problems {
+		args = append(args, problem)
+	}
+	format := "%w: " + strings.TrimSuffix(strings.Repeat("%w; ", len(problems)), "; ")
+	return fmt.Errorf(format, args...)
+}
+
+// timeout returns the effective timeout, preferring RequestTimeout over the deprecated Timeout
+func (c Config) timeout() time.Duration {
+	if c.RequestTimeout > 0 {
+		return c.RequestTimeout
+	}
+	return time.Duration(c.Timeout) * time.Second
 }
 
 // mergeConfig merges default and user-defined configurations
@@ -21,6 +237,11 @@ func mergeConfig(defaultConfig, userConfig Config) Config {
 		finalConfig.Method = userConfig.Method
 	}
 
+	// Merge base URL
+	if userConfig.BaseURL != "" {
+		finalConfig.BaseURL = userConfig.BaseURL
+	}
+
 	// Merge URL
 	if userConfig.URL != "" {
 		finalConfig.URL = userConfig.URL
@@ -29,46 +250,212 @@ func mergeConfig(defaultConfig, userConfig Config) Config {
 	// Merge Headers
 	finalConfig.Headers = mergeHeaders(defaultConfig.Headers, userConfig.Headers)
 
+	// Merge User-Agent
+	if userConfig.UserAgent != "" {
+		finalConfig.UserAgent = userConfig.UserAgent
+	}
+
+	// Merge credentials
+	if userConfig.BasicAuth != nil {
+		finalConfig.BasicAuth = userConfig.BasicAuth
+	}
+	if userConfig.BearerToken != "" {
+		finalConfig.BearerToken = userConfig.BearerToken
+	}
+
 	// Merge Query Params
 	finalConfig.Params = mergeParams(defaultConfig.Params, userConfig.Params)
 
+	// Merge path params without modifying the defaults
+	if len(userConfig.PathParams) > 0 {
+		finalConfig.PathParams = maps.Clone(defaultConfig.PathParams)
+		if finalConfig.PathParams == nil {
+			finalConfig.PathParams = make(map[string]string, len(userConfig.PathParams))
+		}
+		maps.Copy(finalConfig.PathParams, userConfig.PathParams)
+	}
+
 	// Merge Body
 	if userConfig.Body != nil {
 		finalConfig.Body = userConfig.Body
 	}
 
+	// Merge JSON body
+	if userConfig.JSONBody != nil {
+		finalConfig.JSONBody = userConfig.JSONBody
+	}
+
+	// Merge MessagePack body and codec
+	if userConfig.MsgpackBody != nil {
+		finalConfig.MsgpackBody = userConfig.MsgpackBody
+	}
+	if userConfig.MsgpackCodec != nil {
+		finalConfig.MsgpackCodec = userConfig.MsgpackCodec
+	}
+
+	// Merge protobuf body and codec
+	if userConfig.ProtoBody != nil {
+		finalConfig.ProtoBody = userConfig.ProtoBody
+	}
+	if userConfig.ProtoCodec != nil {
+		finalConfig.ProtoCodec = userConfig.ProtoCodec
+	}
+
+	// Merge form body
+	if userConfig.Form != nil {
+		finalConfig.Form = userConfig.Form
+	}
+
+	// Merge multipart fields and files
+	if userConfig.Fields != nil {
+		finalConfig.Fields = userConfig.Fields
+	}
+	if userConfig.Files != nil {
+		finalConfig.Files = userConfig.Files
+	}
+
+	// Merge streamed body
+	if userConfig.BodyReader != nil {
+		finalConfig.BodyReader = userConfig.BodyReader
+		finalConfig.ContentLength = userConfig.ContentLength
+	}
+
+	// Merge request compression
+	if userConfig.CompressRequest {
+		finalConfig.CompressRequest = true
+	}
+
+	// Merge response size and read time limits
+	if userConfig.MaxResponseBytes > 0 {
+		finalConfig.MaxResponseBytes = userConfig.MaxResponseBytes
+	}
+	if userConfig.BodyReadTimeout > 0 {
+		finalConfig.BodyReadTimeout = userConfig.BodyReadTimeout
+	}
+
+	// Merge Result target
+	if userConfig.Result != nil {
+		finalConfig.Result = userConfig.Result
+	}
+
+	// Merge streaming mode
+	if userConfig.Stream {
+		finalConfig.Stream = true
+	}
+	if userConfig.ResponseWriter != nil {
+		finalConfig.ResponseWriter = userConfig.ResponseWriter
+	}
+
+	// Merge tracing
+	if userConfig.Trace {
+		finalConfig.Trace = true
+	}
+
+	// Merge request deduplication
+	if userConfig.Dedupe {
+		finalConfig.Dedupe = true
+	}
+
+	// Merge progress callbacks
+	if userConfig.OnUploadProgress != nil {
+		finalConfig.OnUploadProgress = userConfig.OnUploadProgress
+	}
+	if userConfig.OnDownloadProgress != nil {
+		finalConfig.OnDownloadProgress = userConfig.OnDownloadProgress
+	}
+
 	// Merge Timeout
 	if userConfig.Timeout != 0 {
 		finalConfig.Timeout = userConfig.Timeout
 	}
+	if userConfig.RequestTimeout != 0 {
+		finalConfig.RequestTimeout = userConfig.RequestTimeout
+	}
+
+	// Merge Retry policy
+	if userConfig.Retry != nil {
+		finalConfig.Retry = userConfig.Retry
+	}
+
+	// Merge idempotency key settings
+	if userConfig.IdempotencyKey != "" {
+		finalConfig.IdempotencyKey = userConfig.IdempotencyKey
+	}
+	if userConfig.AutoIdempotencyKey {
+		finalConfig.AutoIdempotencyKey = true
+	}
+
+	// Merge interceptor values
+	finalConfig.Values = mergeValues(defaultConfig.Values, userConfig.Values)
+
+	// Merge status validation
+	if userConfig.ValidateStatus != nil {
+		finalConfig.ValidateStatus = userConfig.ValidateStatus
+	}
+	if userConfig.NoStatusError {
+		finalConfig.NoStatusError = true
+	}
+
+	// Merge redirect policy
+	if userConfig.MaxRedirects != 0 {
+		finalConfig.MaxRedirects = userConfig.MaxRedirects
+	}
+	if userConfig.FollowRedirects != nil {
+		finalConfig.FollowRedirects = userConfig.FollowRedirects
+	}
+	if userConfig.CheckRedirect != nil {
+		finalConfig.CheckRedirect = userConfig.CheckRedirect
+	}
+	if userConfig.TrackRedirects {
+		finalConfig.TrackRedirects = true
+	}
+	if userConfig.DisableKeepAlive {
+		finalConfig.DisableKeepAlive = true
+	}
+
+	// Merge request construction
+	if userConfig.RequestBuilder != nil {
+		finalConfig.RequestBuilder = userConfig.RequestBuilder
+	}
 
 	return finalConfig
 }
 
-// mergeHeaders merges two HTTP header sets with user-defined headers overriding the defaults
+// mergeHeaders merges two HTTP header sets into a new one, with user-defined headers
+// replacing all values of the same default header. Neither input is modified, since the
+// defaults are shared by concurrent requests.
 func mergeHeaders(defaultHeaders, userHeaders http.Header) http.Header {
-	if defaultHeaders == nil {
-		defaultHeaders = http.Header{}
+	merged := make(http.Header, len(defaultHeaders)+len(userHeaders))
+	for key, values := range defaultHeaders {
+		merged[http.CanonicalHeaderKey(key)] = slices.Clone(values)
 	}
-
 	for key, values := range userHeaders {
-		for _, value := range values {
-			defaultHeaders.Set(key, value) // Overwrites existing headers
-		}
+		merged[http.CanonicalHeaderKey(key)] = slices.Clone(values)
 	}
-
-	return defaultHeaders
+	return merged
 }
 
-// mergeParams merges query parameters, prioritizing user-defined ones
+// mergeParams merges query parameters into a new map, prioritizing user-defined ones.
+// Neither input is modified.
 func mergeParams(defaultParams, userParams map[string]string) map[string]string {
-	if defaultParams == nil {
-		defaultParams = make(map[string]string)
-	}
+	merged := make(map[string]string, len(defaultParams)+len(userParams))
+	maps.Copy(merged, defaultParams)
+	maps.Copy(merged, userParams) // Overwrites existing parameters
+	return merged
+}
 
-	for key, value := range userParams {
-		defaultParams[key] = value // Overwrites existing parameters
+// mergeValues merges interceptor values into a new map, prioritizing user-defined ones
+func mergeValues(defaultValues, userValues map[string]interface{}) map[string]interface{} {
+	if len(defaultValues) == 0 && len(userValues) == 0 {
+		return nil
 	}
 
-	return defaultParams
+	values := make(map[string]interface{}, len(defaultValues)+len(userValues))
+	for key, value := range defaultValues {
+		values[key] = value
+	}
+	for key, value := range userValues {
+		values[key] = value
+	}
+	return values
 }
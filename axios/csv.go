@@ -0,0 +1,183 @@
+package axios
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// CSVOptions controls how ParseCSV and ParseCSVInto read a CSV body
+type CSVOptions struct {
+	Comma rune // Field delimiter, e.g. ';' or '\t'; defaults to ','
+
+	// SkipHeader drops the first row from ParseCSV's result. ParseCSVInto always reads
+	// the first row as the header.
+	SkipHeader bool
+}
+
+// ParseCSV parses a CSV body into its rows; opts may be nil. Rows may have differing
+// numbers of fields.
+func (r *Response) ParseCSV(opts *CSVOptions) ([][]string, error) {
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	rows, err := readCSV(r.Body, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.SkipHeader && len(rows) > 0 {
+		rows = rows[1:]
+	}
+	return rows, nil
+}
+
+// ParseCSVInto decodes a CSV body into v, a pointer to a slice of structs or struct
+// pointers; opts may be nil. The first row is the header, and each column is stored in
+// the field whose `csv:"name"` tag, or else name, matches its header case-insensitively.
+// Columns without a field, fields tagged `csv:"-"` and fields promoted through embedded
+// struct pointers are skipped. Fields may be strings, booleans, numbers or implement
+// encoding.TextUnmarshaler.
+func (r *Response) ParseCSVInto(v interface{}, opts *CSVOptions) error {
+	slice := reflect.ValueOf(v)
+	if slice.Kind() != reflect.Pointer || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("error parsing CSV: cannot decode into %T, need a pointer to a slice", v)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("error parsing CSV: cannot decode into %T, need a slice of structs", v)
+	}
+
+	if opts == nil {
+		opts = &CSVOptions{}
+	}
+	rows, err := readCSV(r.Body, opts)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("error parsing CSV: %w", ErrEmptyBody)
+	}
+
+	columns := csvColumns(rows[0], structType) // Field index for each column, or nil
+	result := reflect.MakeSlice(slice.Type(), 0, len(rows)-1)
+	for line, row := range rows[1:] {
+		elem := reflect.New(structType)
+		for column, value := range row {
+			if column >= len(columns) || columns[column] == nil {
+				continue
+			}
+			if err := setCSVField(elem.Elem().FieldByIndex(columns[column]), value); err != nil {
+				return fmt.Errorf("error parsing CSV: row %d, column %q: %w", line+2, rows[0][column], err)
+			}
+		}
+		if elemType.Kind() != reflect.Pointer {
+			elem = elem.Elem()
+		}
+		result = reflect.Append(result, elem)
+	}
+	slice.Set(result)
+	return nil
+}
+
+// readCSV reads every row of a CSV body
+func readCSV(body []byte, opts *CSVOptions) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.FieldsPerRecord = -1
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CSV: %w", err)
+	}
+	return rows, nil
+}
+
+// csvColumns maps each header to the index of the struct field it is stored in
+func csvColumns(header []string, structType reflect.Type) [][]int {
+	fields := make(map[string][]int)
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || field.Anonymous || throughPointer(structType, field.Index) {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("csv"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields[strings.ToLower(name)] = field.Index
+	}
+
+	columns := make([][]int, len(header))
+	for i, name := range header {
+		columns[i] = fields[strings.ToLower(strings.TrimSpace(name))]
+	}
+	return columns
+}
+
+// throughPointer reports whether the field at index is promoted through an embedded struct
+// pointer, which would be nil in a freshly allocated struct
+func throughPointer(structType reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		structType = structType.Field(i).Type
+		if structType.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// setCSVField parses value into field according to the field's type
+func setCSVField(field reflect.Value, value string) error {
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+	if value == "" && field.Kind() != reflect.String {
+		return nil // Empty cells leave non-string fields at their zero value
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
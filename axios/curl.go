@@ -0,0 +1,153 @@
+package axios
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ToCurl renders the request that config would send as an equivalent curl command, for
+// debugging and sharing reproductions. It merges the client's defaults and applies
+// credentials and default headers like Request does, but does not run interceptors, since
+// they may have side effects. Binary bodies are piped in through printf, multipart fields
+// and files become form options, and a BodyReader that cannot be rewound is read from
+// stdin instead of being consumed.
+func (c *Client) ToCurl(config Config) (string, error) {
+	finalConfig := mergeConfig(c.config, config)
+	finalConfig.Method = requestMethod(finalConfig.Method)
+	requestURL, err := finalURL(finalConfig)
+	if err != nil {
+		return "", err
+	}
+
+	multipart := finalConfig.Fields != nil || finalConfig.Files != nil
+	var body requestBody
+	if !multipart {
+		if body, err = encodeRequestBody(finalConfig); err != nil {
+			return "", fmt.Errorf("preparing request body: %w", err)
+		}
+	} else if bodyFieldCount(finalConfig) > 1 {
+		return "", ErrConflictingBody
+	}
+
+	req, err := http.NewRequest(finalConfig.Method, requestURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	applyConfigHeaders(req, finalConfig)
+	applyHeaders(req, finalConfig, body)
+
+	args := []string{"curl"}
+	if req.Method != http.MethodGet {
+		args = append(args, "-X", shellQuote(req.Method))
+	}
+	args = append(args, shellQuote(requestURL))
+	if finalConfig.FollowRedirects == nil || *finalConfig.FollowRedirects {
+		args = append(args, "-L")
+		if finalConfig.MaxRedirects > 0 {
+			args = append(args, "--max-redirs", fmt.Sprint(finalConfig.MaxRedirects))
+		}
+	}
+
+	// Headers are sorted so the command is stable; curl sets its own multipart Content-Type
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		if !(multipart && name == "Content-Type") {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			args = append(args, "-H", shellQuote(name+": "+value))
+		}
+	}
+
+	switch {
+	case multipart:
+		fields := make([]string, 0, len(finalConfig.Fields))
+		for name := range finalConfig.Fields {
+			fields = append(fields, name)
+		}
+		slices.Sort(fields)
+		for _, name := range fields {
+			args = append(args, "--form-string", shellQuote(name+"="+finalConfig.Fields[name]))
+		}
+		for _, file := range finalConfig.Files {
+			args = append(args, "-F", shellQuote(file.FieldName+"=@"+file.FileName))
+		}
+
+	case body.reader != nil:
+		var data []byte
+		switch _, seekable := finalConfig.BodyReader.(io.Seeker); {
+		case body.getBody != nil:
+			if data, err = readBody(body.getBody); err != nil {
+				return "", err
+			}
+		case seekable:
+			if data, err = io.ReadAll(body.reader); err != nil { // Request rewinds the seeker before sending
+				return "", fmt.Errorf("reading request body: %w", err)
+			}
+		default:
+			args = append(args, "--data-binary", "@-")
+			return strings.Join(args, " "), nil
+		}
+
+		// Binary bodies are piped in, since shell strings cannot hold NUL bytes
+		if isText(data) {
+			args = append(args, "--data-binary", shellQuote(string(data)))
+		} else {
+			args = append(args, "--data-binary", "@-")
+			return "printf " + printfQuote(data) + " | " + strings.Join(args, " "), nil
+		}
+	}
+	return strings.Join(args, " "), nil
+}
+
+// readBody reads a full copy of an in-memory body
+func readBody(getBody func() (io.ReadCloser, error)) ([]byte, error) {
+	reader, err := getBody()
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+	return data, nil
+}
+
+// shellQuote single-quotes s for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// isText reports whether data is valid UTF-8 without control characters other than whitespace
+func isText(data []byte) bool {
+	return utf8.Valid(data) && !strings.ContainsFunc(string(data), func(r rune) bool {
+		return r < 0x20 && r != '\n' && r != '\t' && r != '\r' || r == 0x7f
+	})
+}
+
+// printfQuote quotes data as a printf format that reproduces it byte for byte, escaping
+// everything but printable ASCII in octal so that binary data, including NUL bytes, survives
+func printfQuote(data []byte) string {
+	var format strings.Builder
+	for _, b := range data {
+		switch {
+		case b == '\\':
+			format.WriteString(`\\`)
+		case b == '%':
+			format.WriteString("%%")
+		case b >= 0x20 && b < 0x7f:
+			format.WriteByte(b)
+		default:
+			fmt.Fprintf(&format, "\\%03o", b)
+		}
+	}
+	return shellQuote(format.String())
+}
@@ -0,0 +1,122 @@
+package axios
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// defaultDebugBodyBytes is used when DebugOptions.MaxBodyBytes is not set
+const defaultDebugBodyBytes = 4096
+
+// DebugOptions controls what SetDebug writes
+type DebugOptions struct {
+	// RedactHeaders lists request and response headers whose values are hidden. Defaults
+	// to Authorization, Proxy-Authorization, Cookie and Set-Cookie; set it to an empty,
+	// non-nil slice to show every header.
+	RedactHeaders []string
+
+	// MaxBodyBytes is how much of each body is written before it is truncated (default 4096)
+	MaxBodyBytes int
+}
+
+// debugLogger writes request and response dumps, one exchange part at a time
+type debugLogger struct {
+	mu      sync.Mutex // Keeps dumps of concurrent requests from interleaving
+	w       io.Writer
+	redact  []string
+	maxBody int
+}
+
+// SetDebug writes every request sent and response received, with method, URL, headers
+// and body, to w; nil disables it. Responses served from the cache are not written. The
+// request dump includes headers the transport adds, such as Host and Accept-Encoding. A
+// streamed body is not read for the dump, and binary bodies are summarized by size. It
+// must not be called concurrently with requests.
+func (c *Client) SetDebug(w io.Writer, opts *DebugOptions) {
+	if w == nil {
+		c.debug = nil
+		return
+	}
+	if opts == nil {
+		opts = &DebugOptions{}
+	}
+
+	logger := &debugLogger{w: w, redact: opts.RedactHeaders, maxBody: opts.MaxBodyBytes}
+	if logger.redact == nil {
+		logger.redact = defaultRedactedHeaders
+	}
+	if logger.maxBody <= 0 {
+		logger.maxBody = defaultDebugBodyBytes
+	}
+	c.debug = logger
+}
+
+// dumpRequest writes req, reading its body from GetBody so the request itself is untouched
+func (d *debugLogger) dumpRequest(req *http.Request) {
+	out := req.Clone(req.Context())
+	out.Header = redactHeaders(req.Header, d.redact)
+	if out.Body != nil && out.Body != http.NoBody {
+		out.Body = io.NopCloser(bytes.NewReader(nil)) // Dumped below, without consuming the real body
+	}
+
+	head, err := httputil.DumpRequestOut(out, false)
+	if err != nil {
+		head = []byte(fmt.Sprintf("%s %s (dump failed: %v)\r\n\r\n", req.Method, req.URL, err))
+	}
+
+	var body []byte
+	streamed := false
+	switch {
+	case req.Body == nil || req.Body == http.NoBody:
+	case req.GetBody != nil:
+		if copied, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(copied)
+			copied.Close()
+		}
+	default:
+		streamed = true
+	}
+	d.write(">>> ", head, body, streamed)
+}
+
+// dumpResponse writes resp's status line and headers along with the already read body
+func (d *debugLogger) dumpResponse(resp *http.Response, body []byte, streamed bool) {
+	out := *resp
+	out.Header = redactHeaders(resp.Header, d.redact)
+	out.Body = nil
+
+	head, err := httputil.DumpResponse(&out, false)
+	if err != nil {
+		head = []byte(fmt.Sprintf("%s (dump failed: %v)\r\n\r\n", resp.Status, err))
+	}
+	d.write("<<< ", head, body, streamed)
+}
+
+// write writes a dump with its body truncated to the configured size
+func (d *debugLogger) write(prefix string, head, body []byte, streamed bool) {
+	var dump bytes.Buffer
+	dump.WriteString(prefix)
+	dump.Write(head)
+	switch {
+	case streamed:
+		dump.WriteString("[streamed body not shown]\n")
+	case len(body) == 0:
+	case !isText(body):
+		fmt.Fprintf(&dump, "[binary body, %d bytes]\n", len(body))
+	case len(body) > d.maxBody:
+		dump.Write(body[:d.maxBody])
+		fmt.Fprintf(&dump, "\n[truncated, %d more bytes]\n", len(body)-d.maxBody)
+	default:
+		dump.Write(body)
+		dump.WriteString("\n")
+	}
+	dump.WriteString("\n")
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.w.Write(dump.Bytes())
+}
@@ -0,0 +1,126 @@
+package axios
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// dedupeKey identifies a request for deduplication by method, URL and the headers,
+// credentials and response handling from its config. Only body-less GET and HEAD requests
+// with Config.Dedupe set are deduplicated; streamed responses, responses copied to a
+// ResponseWriter and downloads reporting progress cannot be shared, since only the leader's
+// would see the body. Neither can requests whose outcome depends on a function or on
+// Values, which the key cannot compare.
+func dedupeKey(config Config, requestURL string) (string, bool) {
+	method := config.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	if !config.Dedupe || config.Stream || config.ResponseWriter != nil || config.OnDownloadProgress != nil ||
+		config.RequestBuilder != nil || config.ValidateStatus != nil || len(config.Values) > 0 ||
+		bodyFieldCount(config) > 0 ||
+		(method != http.MethodGet && method != http.MethodHead) {
+		return "", false
+	}
+
+	var key strings.Builder
+	key.WriteString(method + " " + requestURL + "\n")
+
+	names := make([]string, 0, len(config.Headers))
+	for name := range config.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key.WriteString(http.CanonicalHeaderKey(name) + ": " + strings.Join(config.Headers[name], ", ") + "\n")
+	}
+
+	key.WriteString("User-Agent: " + config.UserAgent + "\n")
+	key.WriteString("Bearer: " + config.BearerToken + "\n")
+	if config.BasicAuth != nil {
+		key.WriteString("Basic: " + config.BasicAuth.Username + ":" + config.BasicAuth.Password + "\n")
+	}
+	fmt.Fprintf(&key, "NoStatusError: %t\nMaxResponseBytes: %d\n", config.NoStatusError, config.MaxResponseBytes)
+	return key.String(), true
+}
+
+// inflightCall is a deduplicated request along with the callers waiting for it
+type inflightCall struct {
+	done     chan struct{} // Closed once response and err are set
+	response *Response
+	err      error
+	waiters  int
+	cancel   context.CancelFunc
+}
+
+// dedupe runs send once for all concurrent callers with the same key, giving each caller
+// its own copy of the shared response. send runs on a context detached from the first
+// caller's, so one caller giving up does not fail the others; each caller stops waiting
+// when its own ctx is done, and the request is cancelled once nobody is waiting for it.
+func (c *Client) dedupe(ctx context.Context, key string, send func(ctx context.Context) (*Response, error)) (*Response, error) {
+	c.inflightMu.Lock()
+	call, ok := c.inflight[key]
+	if !ok {
+		sendCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &inflightCall{done: make(chan struct{}), cancel: cancel}
+		if c.inflight == nil {
+			c.inflight = make(map[string]*inflightCall)
+		}
+		c.inflight[key] = call
+
+		go func() {
+			defer cancel()
+			call.response, call.err = send(sendCtx)
+			c.forget(key, call)
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	c.inflightMu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		c.inflightMu.Lock()
+		if call.waiters--; call.waiters == 0 {
+			c.forgetLocked(key, call)
+			call.cancel()
+		}
+		c.inflightMu.Unlock()
+		return nil, fmt.Errorf("executing request: %w", ctx.Err())
+	}
+
+	response := call.response
+	if response != nil {
+		response = response.clone()
+	}
+	return response, call.err
+}
+
+// forget removes call from the in-flight requests, so later callers send a new request
+func (c *Client) forget(key string, call *inflightCall) {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+	c.forgetLocked(key, call)
+}
+
+// forgetLocked is forget for callers holding inflightMu
+func (c *Client) forgetLocked(key string, call *inflightCall) {
+	if c.inflight[key] == call {
+		delete(c.inflight, key)
+	}
+}
+
+// clone returns a copy of the response whose body and headers can be modified independently
+func (r *Response) clone() *Response {
+	clone := *r
+	clone.Body = bytes.Clone(r.Body)
+	clone.Headers = r.Headers.Clone()
+	clone.Redirects = slices.Clone(r.Redirects)
+	return &clone
+}
@@ -0,0 +1,48 @@
+package axios
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Download streams the response body to destPath without buffering it in memory.
+// The body is written to a temporary file next to destPath, which replaces destPath only
+// once the download completes; if it fails, including when ctx is canceled mid-transfer,
+// the temporary file is removed and an existing file at destPath is left untouched.
+func (c *Client) Download(ctx context.Context, config Config, destPath string) (err error) {
+	resp, err := c.RequestStream(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer resp.RawBody.Close()
+
+	// Same directory as destPath, so the final rename stays on one filesystem
+	file, err := os.CreateTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating download file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name()) // Don't leave a partial file behind
+		}
+	}()
+
+	// io.Copy writes in chunks; reads fail once the request context is canceled
+	if _, err := io.Copy(file, resp.RawBody); err != nil {
+		return fmt.Errorf("writing download: %w", err)
+	}
+	if err := file.Chmod(0o644); err != nil { // CreateTemp makes the file private to its owner
+		return fmt.Errorf("setting download file mode: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing download file: %w", err)
+	}
+	if err := os.Rename(file.Name(), destPath); err != nil {
+		return fmt.Errorf("moving download into place: %w", err)
+	}
+	return nil
+}
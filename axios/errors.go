@@ -1,18 +1,85 @@
 package axios
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 )
 
+// ErrInvalidConfig is returned by Config.Validate, alongside the specific problems found
+var ErrInvalidConfig = errors.New("invalid config")
+
+// ErrConflictingBody is returned when more than one request body field is set on a Config
+var ErrConflictingBody = errors.New("conflicting request body fields: set only one of Body, JSONBody, MsgpackBody, ProtoBody, Form, Fields/Files or BodyReader")
+
+// ErrEmptyBody is returned when decoding a response that has no body
+var ErrEmptyBody = errors.New("empty response body")
+
+// ErrUnsupportedContentType is returned by Response.Decode for a Content-Type it cannot decode
+var ErrUnsupportedContentType = errors.New("unsupported Content-Type")
+
+// ErrTooManyRedirects is returned when a redirect chain exceeds Config.MaxRedirects
+var ErrTooManyRedirects = errors.New("too many redirects")
+
+// ErrBodyReadTimeout is returned when reading a response body takes longer than
+// Config.BodyReadTimeout, as opposed to a timeout while connecting or awaiting headers
+var ErrBodyReadTimeout = errors.New("timeout reading response body")
+
+// ErrInvalidURL is returned when the request URL is empty, malformed, or not absolute
+// after resolving it against the base URL
+var ErrInvalidURL = errors.New("invalid URL")
+
+// ErrBodyNotReplayable is returned when an interceptor's Replay hook asks to resend a request
+// whose streamed body is not seekable, so it cannot be sent again
+var ErrBodyNotReplayable = errors.New("request body cannot be replayed")
+
+// ErrResponseTooLarge is returned when a response body exceeds Config.MaxResponseBytes
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// DecodeError reports a response that was received successfully but could not be decoded,
+// distinguishing it from transport and status errors
+type DecodeError struct {
+	Err error
+}
+
+// Error returns the underlying decode error message
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding response: %v", e.Err)
+}
+
+// Unwrap returns the underlying decode error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// responseWriteError reports a failure while copying a response body into
+// Config.ResponseWriter, after which part of the body may already have been written
+type responseWriteError struct {
+	err error
+}
+
+// Error returns the underlying copy error message
+func (e *responseWriteError) Error() string {
+	return fmt.Sprintf("writing response body: %v", e.err)
+}
+
+// Unwrap returns the underlying copy error
+func (e *responseWriteError) Unwrap() error {
+	return e.err
+}
+
 // RequestError represents an error that occurred during an HTTP request
 type RequestError struct {
 	StatusCode int
 	Method     string
 	URL        string
 	Message    string
-	Body       string // Optional: Store the response body for detailed error messages
+	Body       string      // Optional: Store the response body for detailed error messages
+	Headers    http.Header // Response headers, e.g. for reading Retry-After
+	Response   *Response   // The rejected response, for inspecting its status, headers and body
 }
 
 // Error returns a detailed formatted error message
@@ -25,20 +92,50 @@ func (e *RequestError) Error() string {
 func HandleResponseError(resp *http.Response) error {
 	if resp.StatusCode >= 400 {
 		// Attempt to read the response body (optional for debugging)
-		var responseBody string
-		body, err := io.ReadAll(resp.Body)
-		if err == nil && len(body) > 0 {
-			responseBody = string(body)
-		}
+		response := newResponse(resp)
+		response.Body, _ = io.ReadAll(resp.Body)
 
 		// Return the error with status code and response details
-		return &RequestError{
-			StatusCode: resp.StatusCode,
-			Method:     resp.Request.Method,
-			URL:        resp.Request.URL.String(),
-			Message:    http.StatusText(resp.StatusCode),
-			Body:       responseBody,
-		}
+		return newRequestError(resp, response)
 	}
 	return nil
 }
+
+// newRequestError builds a RequestError for the request that produced resp,
+// reporting the status, headers and body of the (possibly intercepted) response
+func newRequestError(resp *http.Response, response *Response) *RequestError {
+	return &RequestError{
+		StatusCode: response.StatusCode,
+		Method:     resp.Request.Method,
+		URL:        resp.Request.URL.String(),
+		Message:    http.StatusText(response.StatusCode),
+		Body:       string(response.Body),
+		Headers:    response.Headers,
+		Response:   response,
+	}
+}
+
+// IsTimeout reports whether err was caused by a timeout, either a context deadline
+// or a network-level timeout such as the client's Timeout elapsing
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrBodyReadTimeout) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsCanceled reports whether err was caused by the request's context being canceled
+func IsCanceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
+// IsStatusError reports whether err was caused by an HTTP error status, returning the RequestError
+func IsStatusError(err error) (*RequestError, bool) {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,68 @@
+package axios
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// GraphQLError is a single entry of a GraphQL response's errors array
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLErrors reports the errors returned by a GraphQL server in an otherwise successful
+// HTTP response, distinguishing them from transport and status errors
+type GraphQLErrors []GraphQLError
+
+// Error joins the messages of all GraphQL errors
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// graphQLRequest is the standard GraphQL request envelope
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the standard GraphQL response envelope
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors GraphQLErrors   `json:"errors"`
+}
+
+// GraphQL posts query and variables to endpoint and decodes the response's data into out,
+// which may be nil. Errors reported by the server are returned as GraphQLErrors, after
+// decoding any partial data; HTTP failures are returned as usual, e.g. as a *RequestError.
+func (c *Client) GraphQL(ctx context.Context, endpoint string, query string, variables map[string]interface{}, out interface{}) error {
+	resp, err := c.Request(ctx, Config{
+		Method:   http.MethodPost,
+		URL:      endpoint,
+		JSONBody: graphQLRequest{Query: query, Variables: variables},
+	})
+	if err != nil {
+		return err
+	}
+
+	var envelope graphQLResponse
+	if err := json.Unmarshal(resp.Body, &envelope); err != nil {
+		return &DecodeError{Err: err}
+	}
+	if out != nil && len(envelope.Data) > 0 && string(envelope.Data) != "null" {
+		if err := json.Unmarshal(envelope.Data, out); err != nil {
+			return &DecodeError{Err: err}
+		}
+	}
+	if len(envelope.Errors) > 0 {
+		return envelope.Errors
+	}
+	return nil
+}
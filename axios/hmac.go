@@ -0,0 +1,87 @@
+package axios
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+)
+
+// ErrInvalidSignature is returned by VerifyHMACRequest when a request's signature is missing or wrong
+var ErrInvalidSignature = errors.New("invalid request signature")
+
+// DefaultHMACMaxBodyBytes is the body size VerifyHMACRequest reads at most when not given a limit
+const DefaultHMACMaxBodyBytes = 10 << 20
+
+// HMACSignInterceptor returns a request interceptor that sets headerName to the hex-encoded
+// HMAC of the request body, computed with secret and hashFn (e.g. sha256.New). Only
+// in-memory bodies can be signed; a streamed body makes the request fail.
+func HMACSignInterceptor(secret []byte, headerName string, hashFn func() hash.Hash) Interceptor {
+	return Interceptor{
+		Request: func(req *http.Request) (*http.Request, error) {
+			var body io.Reader = http.NoBody
+			if req.Body != nil && req.Body != http.NoBody {
+				if req.GetBody == nil {
+					return nil, errors.New("signing request: streamed bodies cannot be signed")
+				}
+				copied, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("reading body for signing: %w", err)
+				}
+				defer copied.Close()
+				body = copied
+			}
+
+			signature, err := hmacHex(secret, hashFn, body)
+			if err != nil {
+				return nil, fmt.Errorf("reading body for signing: %w", err)
+			}
+			req.Header.Set(headerName, signature)
+			return req, nil
+		},
+	}
+}
+
+// VerifyHMACRequest checks the signature set by HMACSignInterceptor on an incoming request,
+// returning ErrInvalidSignature if it does not match. The body is read into memory and
+// replaced so the handler can still read it. Since this happens before the signature is
+// checked, at most maxBodyBytes are read (DefaultHMACMaxBodyBytes when <= 0); a larger body
+// fails with an error wrapping *http.MaxBytesError.
+func VerifyHMACRequest(req *http.Request, secret []byte, headerName string, hashFn func() hash.Hash, maxBodyBytes int64) error {
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultHMACMaxBodyBytes
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(http.MaxBytesReader(nil, req.Body, maxBodyBytes))
+		req.Body.Close()
+		if err != nil {
+			return fmt.Errorf("reading body for verification: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	want, err := hmacHex(secret, hashFn, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(want), []byte(req.Header.Get(headerName))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// hmacHex returns the hex-encoded HMAC of everything read from body
+func hmacHex(secret []byte, hashFn func() hash.Hash, body io.Reader) (string, error) {
+	mac := hmac.New(hashFn, secret)
+	if _, err := io.Copy(mac, body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
@@ -1,19 +1,84 @@
 package axios
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"net/http"
+	"slices"
+	"sync"
 )
 
-// Interceptor defines functions for request and response interception
+// Interceptor defines functions for request and response interception.
+// Request interceptors can read per-call metadata from Config.Values with RequestValue,
+// or any value stored on the request's context; response interceptors can use
+// Response.Config.Values and Response.Request.Context() the same way.
+//
+// Request interceptors that need the body, e.g. to sign it, can read a copy from
+// req.GetBody, which is set for in-memory bodies (Body, JSONBody and Form) but not
+// for streamed ones.
 type Interceptor struct {
 	Request  func(*http.Request) (*http.Request, error)
 	Response func(*Response) (*Response, error)
+
+	// Replay is called with the response to each attempt, including one rejected by
+	// Config.ValidateStatus, before response-level retries are considered, and reports
+	// whether the client should resend the request right away, e.g. after refreshing an
+	// expired token. Each interceptor can have a request replayed at most once, and a
+	// replay does not count against Config.Retry.
+	Replay func(ctx context.Context, resp *Response) (bool, error)
+
+	// Error is called with the error a request failed with (transport, status or otherwise),
+	// after any retries, and returns the error to report instead. Returning nil keeps the error.
+	Error func(error) error
+}
+
+// valuesKey is the context key under which Config.Values are stored for interceptors
+type valuesKey struct{}
+
+// withValues attaches the per-request values to the context
+func withValues(ctx context.Context, values map[string]interface{}) context.Context {
+	if len(values) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, valuesKey{}, values)
+}
+
+// RequestValue returns the Config.Values entry for key on the request, if present
+func RequestValue(req *http.Request, key string) (interface{}, bool) {
+	values, _ := req.Context().Value(valuesKey{}).(map[string]interface{})
+	value, ok := values[key]
+	return value, ok
 }
 
-// InterceptorManager manages the addition and execution of interceptors
+// skipInterceptorsKey is the context key under which WithSkipInterceptors stores its selection
+type skipInterceptorsKey struct{}
+
+// WithSkipInterceptors returns a context whose requests bypass the interceptors with the
+// given IDs, or all interceptors when no IDs are given. This lets an auth interceptor's
+// token refresh request avoid being intercepted itself.
+func WithSkipInterceptors(ctx context.Context, ids ...int) context.Context {
+	return context.WithValue(ctx, skipInterceptorsKey{}, ids)
+}
+
+// skipsInterceptor reports whether ctx asks to bypass the interceptor with the given ID
+func skipsInterceptor(ctx context.Context, id int) bool {
+	ids, ok := ctx.Value(skipInterceptorsKey{}).([]int)
+	return ok && (len(ids) == 0 || slices.Contains(ids, id))
+}
+
+// InterceptorManager manages the addition and execution of interceptors.
+// It is safe for concurrent use, so interceptors can be swapped on a live client.
 type InterceptorManager struct {
-	interceptors []Interceptor
+	mu           sync.RWMutex
+	interceptors []registeredInterceptor
+	nextID       int
+}
+
+// registeredInterceptor pairs an interceptor with the ID returned by AddInterceptor
+type registeredInterceptor struct {
+	id int
+	Interceptor
 }
 
 // NewInterceptorManager initializes a new InterceptorManager
@@ -23,18 +88,152 @@ func NewInterceptorManager() *InterceptorManager {
 	}
 }
 
-// AddInterceptor registers an interceptor, lazily initializing the slice if needed
-func (im *InterceptorManager) AddInterceptor(i Interceptor) {
-	if im.interceptors == nil {
-		im.interceptors = []Interceptor{}
+// AddInterceptor registers an interceptor and returns an ID that can be passed to RemoveInterceptor
+func (im *InterceptorManager) AddInterceptor(i Interceptor) int {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	im.nextID++
+	im.interceptors = append(im.interceptors, registeredInterceptor{id: im.nextID, Interceptor: i})
+	return im.nextID
+}
+
+// PrependInterceptor registers an interceptor that runs before all those already registered,
+// e.g. to see requests after every other interceptor has modified them, and returns its ID
+func (im *InterceptorManager) PrependInterceptor(i Interceptor) int {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	im.nextID++
+	// Build a new slice, since snapshots of the current one may still be running
+	im.interceptors = append([]registeredInterceptor{{id: im.nextID, Interceptor: i}}, im.interceptors...)
+	return im.nextID
+}
+
+// AddRequestInterceptor registers a request-only interceptor and returns its ID.
+// Request interceptors run in the order they were added, independently of response interceptors;
+// use PrependRequestInterceptor to run one first.
+func (im *InterceptorManager) AddRequestInterceptor(fn func(*http.Request) (*http.Request, error)) int {
+	return im.AddInterceptor(Interceptor{Request: fn})
+}
+
+// PrependRequestInterceptor registers a request-only interceptor that runs before the request
+// interceptors already registered, and returns its ID
+func (im *InterceptorManager) PrependRequestInterceptor(fn func(*http.Request) (*http.Request, error)) int {
+	return im.PrependInterceptor(Interceptor{Request: fn})
+}
+
+// AddResponseInterceptor registers a response-only interceptor and returns its ID.
+// Response interceptors run in the order they were added, independently of request interceptors;
+// use PrependResponseInterceptor to run one first.
+func (im *InterceptorManager) AddResponseInterceptor(fn func(*Response) (*Response, error)) int {
+	return im.AddInterceptor(Interceptor{Response: fn})
+}
+
+// PrependResponseInterceptor registers a response-only interceptor that runs before the response
+// interceptors already registered, and returns its ID
+func (im *InterceptorManager) PrependResponseInterceptor(fn func(*Response) (*Response, error)) int {
+	return im.PrependInterceptor(Interceptor{Response: fn})
+}
+
+// AddErrorInterceptor registers an error-only interceptor and returns its ID
+func (im *InterceptorManager) AddErrorInterceptor(fn func(error) error) int {
+	return im.AddInterceptor(Interceptor{Error: fn})
+}
+
+// RemoveInterceptor unregisters the interceptor with the given ID, reporting whether it was found
+func (im *InterceptorManager) RemoveInterceptor(id int) bool {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	for idx, registered := range im.interceptors {
+		if registered.id == id {
+			im.interceptors = append(im.interceptors[:idx:idx], im.interceptors[idx+1:]...)
+			return true
+		}
 	}
-	im.interceptors = append(im.interceptors, i)
+	return false
 }
 
-// ApplyRequestInterceptors applies all request interceptors in sequence, stopping if any returns an error
+// ClearInterceptors unregisters all interceptors
+func (im *InterceptorManager) ClearInterceptors() {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	im.interceptors = nil
+}
+
+// clone returns an independent manager with the same interceptors and IDs
+func (im *InterceptorManager) clone() *InterceptorManager {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	return &InterceptorManager{
+		interceptors: slices.Clone(im.interceptors),
+		nextID:       im.nextID,
+	}
+}
+
+// snapshot returns the currently registered interceptors, so they can run without holding the lock
+func (im *InterceptorManager) snapshot() []registeredInterceptor {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	return im.interceptors
+}
+
+// hasRequestInterceptors reports whether any request interceptor is registered; it is
+// safe to call on a nil manager
+func (im *InterceptorManager) hasRequestInterceptors() bool {
+	if im == nil {
+		return false
+	}
+	return slices.ContainsFunc(im.snapshot(), func(i registeredInterceptor) bool {
+		return hasRequest(i.Interceptor)
+	})
+}
+
+// active returns the registered interceptors that ctx does not ask to skip
+func (im *InterceptorManager) active(ctx context.Context) []registeredInterceptor {
+	interceptors := im.snapshot()
+	if ctx.Value(skipInterceptorsKey{}) == nil {
+		return interceptors
+	}
+	return slices.DeleteFunc(slices.Clone(interceptors), func(i registeredInterceptor) bool {
+		return skipsInterceptor(ctx, i.id)
+	})
+}
+
+// chain yields the active interceptors that set the hook checked by defines, with their
+// position among all active interceptors. Any hook may be left nil, e.g. a response-only
+// interceptor has no Request, so those interceptors are passed over rather than called.
+func (im *InterceptorManager) chain(ctx context.Context, defines func(Interceptor) bool) iter.Seq2[int, registeredInterceptor] {
+	return func(yield func(int, registeredInterceptor) bool) {
+		for idx, interceptor := range im.active(ctx) {
+			if defines(interceptor.Interceptor) && !yield(idx, interceptor) {
+				return
+			}
+		}
+	}
+}
+
+// hasRequest selects the request chain for chain
+func hasRequest(i Interceptor) bool { return i.Request != nil }
+
+// hasResponse selects the response chain for chain
+func hasResponse(i Interceptor) bool { return i.Response != nil }
+
+// hasReplay selects the replay hooks for chain
+func hasReplay(i Interceptor) bool { return i.Replay != nil }
+
+// hasError selects the error chain for chain
+func hasError(i Interceptor) bool { return i.Error != nil }
+
+// ApplyRequestInterceptors applies all request interceptors in sequence, stopping if any returns an error.
+// Interceptors skipped through WithSkipInterceptors on the request's context are not applied.
 func (im *InterceptorManager) ApplyRequestInterceptors(req *http.Request) (*http.Request, error) {
 	var err error
-	for idx, interceptor := range im.interceptors {
+	for idx, interceptor := range im.chain(req.Context(), hasRequest) {
 		req, err = interceptor.Request(req)
 		if err != nil {
 			return nil, fmt.Errorf("request interceptor %d failed: %w", idx, err)
@@ -43,10 +242,16 @@ func (im *InterceptorManager) ApplyRequestInterceptors(req *http.Request) (*http
 	return req, nil
 }
 
-// ApplyResponseInterceptors applies all response interceptors in sequence, stopping if any returns an error
+// ApplyResponseInterceptors applies all response interceptors in sequence, stopping if any returns an error.
+// Interceptors skipped through WithSkipInterceptors on the context of resp.Request are not applied.
 func (im *InterceptorManager) ApplyResponseInterceptors(resp *Response) (*Response, error) {
+	ctx := context.Background()
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+	}
+
 	var err error
-	for idx, interceptor := range im.interceptors {
+	for idx, interceptor := range im.chain(ctx, hasResponse) {
 		resp, err = interceptor.Response(resp)
 		if err != nil {
 			return nil, fmt.Errorf("response interceptor %d failed: %w", idx, err)
@@ -54,3 +259,38 @@ func (im *InterceptorManager) ApplyResponseInterceptors(resp *Response) (*Respon
 	}
 	return resp, nil
 }
+
+// replay runs the Replay hooks of the interceptors that ctx does not skip and that have not
+// yet replayed this request, recording in replayed the one that asks for a replay
+func (im *InterceptorManager) replay(ctx context.Context, resp *Response, replayed map[int]bool) (bool, error) {
+	for idx, interceptor := range im.chain(ctx, hasReplay) {
+		if replayed[interceptor.id] {
+			continue // It already had its replay
+		}
+		replay, err := interceptor.Replay(ctx, resp)
+		if err != nil {
+			return false, fmt.Errorf("replay interceptor %d failed: %w", idx, err)
+		}
+		if replay {
+			replayed[interceptor.id] = true
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ApplyErrorInterceptors passes err through all error interceptors in sequence.
+// An interceptor returning nil leaves the error unchanged.
+func (im *InterceptorManager) ApplyErrorInterceptors(err error) error {
+	return im.applyErrorInterceptors(context.Background(), err)
+}
+
+// applyErrorInterceptors passes err through the error interceptors that ctx does not skip
+func (im *InterceptorManager) applyErrorInterceptors(ctx context.Context, err error) error {
+	for _, interceptor := range im.chain(ctx, hasError) {
+		if replaced := interceptor.Error(err); replaced != nil {
+			err = replaced
+		}
+	}
+	return err
+}
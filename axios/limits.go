@@ -0,0 +1,57 @@
+package axios
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RateLimiter throttles outgoing requests. *rate.Limiter from golang.org/x/time/rate
+// satisfies this interface.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// SetRateLimiter makes every request attempt, including retries, wait for the limiter
+// before being sent; nil removes throttling. It must not be called concurrently with requests.
+func (c *Client) SetRateLimiter(limiter RateLimiter) {
+	c.rateLimiter = limiter
+}
+
+// waitRateLimit blocks until the rate limiter allows another request or ctx is done
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	if c.rateLimiter == nil {
+		return nil
+	}
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("waiting for rate limiter: %w", err)
+	}
+	return nil
+}
+
+// SetMaxConcurrency bounds the number of requests in flight at once; n <= 0 removes the limit.
+// A streamed response holds its slot until its RawBody is closed. It must not be called
+// concurrently with requests.
+func (c *Client) SetMaxConcurrency(n int) {
+	if n <= 0 {
+		c.semaphore = nil
+		return
+	}
+	c.semaphore = make(chan struct{}, n)
+}
+
+// acquireSlot waits for a free concurrency slot, returning the idempotent function that frees it
+func (c *Client) acquireSlot(ctx context.Context) (func(), error) {
+	semaphore := c.semaphore
+	if semaphore == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-semaphore }) }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a concurrency slot: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,115 @@
+package axios
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// DefaultClient sends the requests of the package-level helpers such as Get and Post. If
+// it is nil when first used, it is set to NewClient(Config{}, nil), which has no timeout
+// besides the context's. Replace it before sending requests to configure the helpers.
+var DefaultClient *Client
+
+// defaultClientOnce guards the lazy creation of DefaultClient
+var defaultClientOnce sync.Once
+
+// defaultClient returns DefaultClient, creating it on first use if it was not set
+func defaultClient() *Client {
+	defaultClientOnce.Do(func() {
+		if DefaultClient == nil {
+			DefaultClient = NewClient(Config{}, nil)
+		}
+	})
+	return DefaultClient
+}
+
+// buildMethodConfig folds optional configs together and sets the method, URL and body
+func buildMethodConfig(method, url string, body []byte, opts []Config) Config {
+	var config Config
+	for _, opt := range opts {
+		config = mergeConfig(config, opt)
+	}
+
+	config.Method = method
+	config.URL = url
+	if body != nil {
+		config.Body = body
+	}
+	return config
+}
+
+// Get sends a GET request to the given URL
+func (c *Client) Get(ctx context.Context, url string, opts ...Config) (*Response, error) {
+	return c.Request(ctx, buildMethodConfig(http.MethodGet, url, nil, opts))
+}
+
+// Post sends a POST request with the given body to the URL
+func (c *Client) Post(ctx context.Context, url string, body []byte, opts ...Config) (*Response, error) {
+	return c.Request(ctx, buildMethodConfig(http.MethodPost, url, body, opts))
+}
+
+// Put sends a PUT request with the given body to the URL
+func (c *Client) Put(ctx context.Context, url string, body []byte, opts ...Config) (*Response, error) {
+	return c.Request(ctx, buildMethodConfig(http.MethodPut, url, body, opts))
+}
+
+// Patch sends a PATCH request with the given body to the URL
+func (c *Client) Patch(ctx context.Context, url string, body []byte, opts ...Config) (*Response, error) {
+	return c.Request(ctx, buildMethodConfig(http.MethodPatch, url, body, opts))
+}
+
+// Delete sends a DELETE request to the given URL
+func (c *Client) Delete(ctx context.Context, url string, opts ...Config) (*Response, error) {
+	return c.Request(ctx, buildMethodConfig(http.MethodDelete, url, nil, opts))
+}
+
+// Head sends a HEAD request to the given URL
+func (c *Client) Head(ctx context.Context, url string, opts ...Config) (*Response, error) {
+	return c.Request(ctx, buildMethodConfig(http.MethodHead, url, nil, opts))
+}
+
+// Options sends an OPTIONS request to the given URL
+func (c *Client) Options(ctx context.Context, url string, opts ...Config) (*Response, error) {
+	return c.Request(ctx, buildMethodConfig(http.MethodOptions, url, nil, opts))
+}
+
+// Request sends a request with DefaultClient
+func Request(ctx context.Context, config Config) (*Response, error) {
+	return defaultClient().Request(ctx, config)
+}
+
+// Get sends a GET request to the given URL with DefaultClient
+func Get(ctx context.Context, url string, opts ...Config) (*Response, error) {
+	return defaultClient().Get(ctx, url, opts...)
+}
+
+// Post sends a POST request with the given body to the URL with DefaultClient
+func Post(ctx context.Context, url string, body []byte, opts ...Config) (*Response, error) {
+	return defaultClient().Post(ctx, url, body, opts...)
+}
+
+// Put sends a PUT request with the given body to the URL with DefaultClient
+func Put(ctx context.Context, url string, body []byte, opts ...Config) (*Response, error) {
+	return defaultClient().Put(ctx, url, body, opts...)
+}
+
+// Patch sends a PATCH request with the given body to the URL with DefaultClient
+func Patch(ctx context.Context, url string, body []byte, opts ...Config) (*Response, error) {
+	return defaultClient().Patch(ctx, url, body, opts...)
+}
+
+// Delete sends a DELETE request to the given URL with DefaultClient
+func Delete(ctx context.Context, url string, opts ...Config) (*Response, error) {
+	return defaultClient().Delete(ctx, url, opts...)
+}
+
+// Head sends a HEAD request to the given URL with DefaultClient
+func Head(ctx context.Context, url string, opts ...Config) (*Response, error) {
+	return defaultClient().Head(ctx, url, opts...)
+}
+
+// Options sends an OPTIONS request to the given URL with DefaultClient
+func Options(ctx context.Context, url string, opts ...Config) (*Response, error) {
+	return defaultClient().Options(ctx, url, opts...)
+}
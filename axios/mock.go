@@ -0,0 +1,199 @@
+package axios
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ErrNoMockResponse is returned by a MockTransport for requests no registered route matches
+var ErrNoMockResponse = errors.New("no mock response registered")
+
+// MockResponder produces the response to a mocked request
+type MockResponder func(*http.Request) (*http.Response, error)
+
+// MockRequest is a request captured by a MockTransport
+type MockRequest struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// mockRoute is a registered method and URL pattern with its responder
+type mockRoute struct {
+	method    string
+	pattern   string
+	responder MockResponder
+}
+
+// MockTransport is an http.RoundTripper that answers requests with registered canned
+// responses instead of doing any I/O, and records every request it receives. It is safe
+// for concurrent use.
+type MockTransport struct {
+	mu       sync.Mutex
+	routes   []mockRoute
+	requests []MockRequest
+}
+
+// NewMockTransport creates a MockTransport with no routes
+func NewMockTransport() *MockTransport {
+	return &MockTransport{}
+}
+
+// Mock replaces the client's transport with a new MockTransport and returns it, so tests
+// can register responses without starting a server. It must not be called concurrently
+// with requests.
+func (c *Client) Mock() *MockTransport {
+	mock := NewMockTransport()
+	c.SetTransport(mock)
+	return mock
+}
+
+// On registers responder for requests with the given method ("" matches any) and URL
+// pattern. Patterns starting with "/" are matched against the URL path, others against
+// scheme://host/path; both use path.Match syntax and ignore the query string. Routes are
+// tried in the order they were registered.
+func (m *MockTransport) On(method, pattern string, responder MockResponder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes = append(m.routes, mockRoute{method: method, pattern: pattern, responder: responder})
+}
+
+// Requests returns the requests received so far, in order
+func (m *MockTransport) Requests() []MockRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockRequest(nil), m.requests...)
+}
+
+// Calls returns how many received requests match the method and URL pattern, using the
+// same rules as On
+func (m *MockTransport) Calls(method, pattern string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := 0
+	for _, req := range m.requests {
+		if req.matches(method, pattern) {
+			calls++
+		}
+	}
+	return calls
+}
+
+// Reset removes all routes and captured requests
+func (m *MockTransport) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes, m.requests = nil, nil
+}
+
+// RoundTrip records req and answers it with the first matching route's responder.
+// The responder gets a copy of req, so req itself is left unmodified as RoundTrippers must.
+func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	original := req
+	captured := MockRequest{Method: req.Method, URL: req.URL.String(), Header: req.Header.Clone()}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading mocked request body: %w", err)
+		}
+		captured.Body = body
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body)) // Let the responder read it again
+	}
+
+	m.mu.Lock()
+	m.requests = append(m.requests, captured)
+	var responder MockResponder
+	for _, route := range m.routes {
+		if captured.matches(route.method, route.pattern) {
+			responder = route.responder
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	if responder == nil {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoMockResponse, req.Method, captured.URL)
+	}
+	resp, err := responder(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Request == nil {
+		resp.Request = original
+	}
+	return resp, nil
+}
+
+// matches reports whether the captured request matches the method and URL pattern
+func (r MockRequest) matches(method, pattern string) bool {
+	if method != "" && !strings.EqualFold(method, r.Method) {
+		return false
+	}
+
+	target, _, _ := strings.Cut(r.URL, "?")
+	if strings.HasPrefix(pattern, "/") {
+		if _, rest, ok := strings.Cut(target, "://"); ok {
+			if i := strings.Index(rest, "/"); i >= 0 {
+				target = rest[i:]
+			} else {
+				target = "/"
+			}
+		}
+	}
+	matched, err := path.Match(pattern, target)
+	return err == nil && matched
+}
+
+// MockString responds with the given status and plain-text body
+func MockString(status int, body string) MockResponder {
+	return MockBytes(status, "text/plain; charset=utf-8", []byte(body))
+}
+
+// MockJSON responds with the given status and v encoded as JSON. It panics if v cannot be
+// encoded, since that is a mistake in the test itself.
+func MockJSON(status int, v interface{}) MockResponder {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("axios: encoding mock JSON: %v", err))
+	}
+	return MockBytes(status, "application/json", body)
+}
+
+// MockBytes responds with the given status, content type and body
+func MockBytes(status int, contentType string, body []byte) MockResponder {
+	return func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+}
+
+// MockError fails the request with err, e.g. to simulate a network failure
+func MockError(err error) MockResponder {
+	return func(*http.Request) (*http.Response, error) {
+		return nil, err
+	}
+}
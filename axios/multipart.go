@@ -0,0 +1,62 @@
+package axios
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"sort"
+)
+
+// FormFile is a file part of a multipart/form-data request body
+type FormFile struct {
+	FieldName string
+	FileName  string
+	Reader    io.Reader // Streamed into the request; rewound before each attempt if it is an io.Seeker
+}
+
+// newMultipartBody streams fields and files as multipart/form-data through a pipe,
+// so file contents are never buffered in memory as a whole
+func newMultipartBody(fields map[string]string, files []FormFile) requestBody {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipart(writer, fields, files))
+	}()
+
+	return requestBody{reader: pr, contentType: writer.FormDataContentType(), length: -1}
+}
+
+// writeMultipart writes all parts and the closing boundary
+func writeMultipart(writer *multipart.Writer, fields map[string]string, files []FormFile) error {
+	// Write fields in a stable order
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := writer.WriteField(key, fields[key]); err != nil {
+			return fmt.Errorf("writing field %q: %w", key, err)
+		}
+	}
+
+	for _, file := range files {
+		if seeker, ok := file.Reader.(io.Seeker); ok {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return fmt.Errorf("rewinding file %q: %w", file.FileName, err)
+			}
+		}
+
+		part, err := writer.CreateFormFile(file.FieldName, file.FileName)
+		if err != nil {
+			return fmt.Errorf("creating part for file %q: %w", file.FileName, err)
+		}
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return fmt.Errorf("writing file %q: %w", file.FileName, err)
+		}
+	}
+
+	return writer.Close()
+}
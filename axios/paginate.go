@@ -0,0 +1,90 @@
+package axios
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// NextPageFunc extracts the URL of the page after resp, or reports that resp is the last page.
+// A relative URL is resolved against the URL that served resp, as RFC 8288 specifies for
+// Link headers, rather than against Config.BaseURL.
+type NextPageFunc func(resp *Response) (nextURL string, done bool)
+
+// Paginate requests config's URL and then every following page found by next, yielding
+// the pages in order. The config's query and path params only apply to the first page;
+// later pages are requested exactly as returned by next. Iteration stops after the first
+// error, which is yielded with a nil response, or when ctx is done.
+//
+//	for page, err := range client.Paginate(ctx, config, axios.NextLink) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) Paginate(ctx context.Context, config Config, next NextPageFunc) iter.Seq2[*Response, error] {
+	return func(yield func(*Response, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			resp, err := c.Request(ctx, config)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(resp, nil) {
+				return
+			}
+
+			nextURL, done := next(resp)
+			if done {
+				return
+			}
+			config.URL = resolveNextPage(resp, nextURL)
+			config.Params = nil
+			config.PathParams = nil
+		}
+	}
+}
+
+// resolveNextPage resolves a relative next page URL against the URL that served resp
+func resolveNextPage(resp *Response, nextURL string) string {
+	base, err := url.Parse(resp.FinalURL)
+	if err != nil || !base.IsAbs() {
+		return nextURL
+	}
+	ref, err := url.Parse(nextURL)
+	if err != nil {
+		return nextURL // Left for the request to report
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// NextLink is a NextPageFunc that follows the rel="next" URL of a response's Link header,
+// as used by GitHub and other APIs (RFC 8288)
+func NextLink(resp *Response) (string, bool) {
+	for _, header := range resp.Headers.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.Trim(strings.TrimSpace(target), "<>"), false
+					}
+				}
+			}
+		}
+	}
+	return "", true
+}
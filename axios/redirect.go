@@ -0,0 +1,94 @@
+package axios
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// defaultMaxRedirects is Go's own redirect limit, used when Config.MaxRedirects is not set
+const defaultMaxRedirects = 10
+
+// Redirect is a single hop of a followed redirect chain
+type Redirect struct {
+	StatusCode int    // The 3xx status that caused the redirect
+	From       string // The URL that answered with the redirect
+	To         string // The URL the redirect led to
+}
+
+// redirectHistoryKey is the context key under which a request's redirect history is recorded
+type redirectHistoryKey struct{}
+
+// redirectHistory collects the redirects followed by a single attempt
+type redirectHistory struct {
+	mu    sync.Mutex
+	hops  []Redirect
+	limit int
+}
+
+// withRedirectHistory returns a context in which followed redirects are recorded into the
+// returned history, up to the config's redirect limit
+func withRedirectHistory(ctx context.Context, config Config) (context.Context, *redirectHistory) {
+	history := &redirectHistory{limit: config.MaxRedirects}
+	if history.limit <= 0 {
+		history.limit = defaultMaxRedirects
+	}
+	return context.WithValue(ctx, redirectHistoryKey{}, history), history
+}
+
+// recordRedirect adds the redirect leading to req, if the request's context tracks redirects
+func recordRedirect(req *http.Request, via []*http.Request) {
+	history, ok := req.Context().Value(redirectHistoryKey{}).(*redirectHistory)
+	if !ok {
+		return
+	}
+
+	hop := Redirect{From: via[len(via)-1].URL.String(), To: req.URL.String()}
+	if req.Response != nil {
+		hop.StatusCode = req.Response.StatusCode
+	}
+
+	history.mu.Lock()
+	defer history.mu.Unlock()
+	if len(history.hops) < history.limit {
+		history.hops = append(history.hops, hop)
+	}
+}
+
+// list returns the recorded redirects
+func (h *redirectHistory) list() []Redirect {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Redirect(nil), h.hops...)
+}
+
+// redirectPolicy builds an http.Client.CheckRedirect function from the redirect
+// settings in config, or returns nil to keep the client's default policy.
+// Go already strips Authorization, Cookie and similar sensitive headers when a
+// redirect leads to a different host, regardless of the policy.
+func redirectPolicy(config Config) func(req *http.Request, via []*http.Request) error {
+	followRedirects := config.FollowRedirects == nil || *config.FollowRedirects
+	if followRedirects && config.MaxRedirects == 0 && config.CheckRedirect == nil && !config.TrackRedirects {
+		return nil
+	}
+
+	return func(req *http.Request, via []*http.Request) error {
+		switch {
+		case !followRedirects || config.MaxRedirects < 0:
+			return http.ErrUseLastResponse // Return the 3xx response itself
+		case config.MaxRedirects > 0 && len(via) > config.MaxRedirects:
+			return fmt.Errorf("%w: stopped after %d redirects", ErrTooManyRedirects, config.MaxRedirects)
+		case config.MaxRedirects == 0 && len(via) >= defaultMaxRedirects:
+			return fmt.Errorf("%w: stopped after %d redirects", ErrTooManyRedirects, defaultMaxRedirects) // Go's default limit
+		}
+
+		if config.CheckRedirect != nil {
+			if err := config.CheckRedirect(req, via); err != nil {
+				return err
+			}
+		}
+		recordRedirect(req, via)
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+package axios
+
+import (
+	"context"
+	"net/http"
+)
+
+// DefaultRequestIDHeader is the header RequestIDInterceptor sets when none is given
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key under which a request's ID is stored
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose requests RequestIDInterceptor tags with id instead
+// of a generated one, e.g. to propagate the ID of an incoming request. Like an ID in
+// Config.Headers, it is sent on every attempt, including retries.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDInterceptor returns a request interceptor that tags every outgoing request with
+// a unique ID in header (X-Request-ID if empty), generated by gen (a random UUID if nil),
+// so it can be correlated across services and logs. An ID already on the request, set in
+// Config.Headers, by WithRequestID or by an earlier interceptor, is kept. Each attempt is
+// intercepted separately, so retries get IDs of their own unless the ID comes from
+// Config.Headers or WithRequestID. Read the ID back with RequestID, e.g. from Response.Request in a response
+// interceptor.
+func RequestIDInterceptor(header string, gen func() string) Interceptor {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+	if gen == nil {
+		gen = newUUID
+	}
+
+	return Interceptor{
+		Request: func(req *http.Request) (*http.Request, error) {
+			if id := req.Header.Get(header); id != "" {
+				return req.WithContext(WithRequestID(req.Context(), id)), nil
+			}
+			id, ok := RequestID(req)
+			if !ok || id == "" {
+				id = gen()
+				req = req.WithContext(WithRequestID(req.Context(), id))
+			}
+			req.Header.Set(header, id)
+			return req, nil
+		},
+	}
+}
+
+// RequestID returns the ID RequestIDInterceptor tagged req with, or one set by WithRequestID
+func RequestID(req *http.Request) (string, bool) {
+	if req == nil {
+		return "", false
+	}
+	id, ok := req.Context().Value(requestIDKey{}).(string)
+	return id, ok
+}
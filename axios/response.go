@@ -1,10 +1,24 @@
 package axios
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"mime"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Response represents the parsed HTTP response
@@ -13,25 +27,247 @@ type Response struct {
 	StatusCode int
 	Body       []byte
 	Headers    http.Header
+
+	// ContentLength is the body's length: the bytes read into Body or written to
+	// Config.ResponseWriter, or for a streamed body the length announced by the server,
+	// -1 when unknown
+	ContentLength int64
+
+	// FinalURL is the URL that served the response, which differs from the requested URL
+	// after redirects
+	FinalURL string
+
+	// FromCache is set when the body was served from the client's cache, either fresh or
+	// after the server confirmed it with a 304, in which case Revalidated is set too
+	FromCache   bool
+	Revalidated bool
+
+	// Redirects lists the redirects followed to get here when Config.TrackRedirects is set
+	Redirects []Redirect
+
+	// RawBody is the open response body when Config.Stream is set; Body is then nil.
+	// The caller must close it.
+	RawBody io.ReadCloser
+
+	// Config and Request describe the request that produced this response,
+	// letting response interceptors inspect or replay it
+	Config  Config
+	Request *http.Request
+
+	// StartedAt is when the request was sent. Duration is the time until the body was
+	// read, or until the headers arrived for a streamed response.
+	StartedAt time.Time
+	Duration  time.Duration
+
+	// Timings breaks down the request's phases when Config.Trace is set, otherwise it is nil
+	Timings *Timings
+}
+
+// newResponse copies the status, headers and final URL of an HTTP response
+func newResponse(resp *http.Response) *Response {
+	response := &Response{
+		Status:        resp.Status,
+		StatusCode:    resp.StatusCode,
+		Headers:       resp.Header,
+		ContentLength: resp.ContentLength,
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		response.FinalURL = resp.Request.URL.String()
+	}
+	return response
 }
 
-// ParseResponse reads and parses the response body into a Response struct
+// ParseResponse reads the whole response body into a Response and closes it. A gzip or
+// deflate body the transport left compressed is decompressed.
 func ParseResponse(resp *http.Response) (*Response, error) {
-	defer resp.Body.Close()
+	if resp.Body == nil {
+		return newResponse(resp), nil
+	}
+	decompressBody(resp)
+	response := newResponse(resp)
 
-	// Read response body
 	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
 	if err != nil {
 		return nil, fmt.Errorf("reading response body: %w", err)
 	}
+	response.Body = body
+	response.ContentLength = int64(len(body))
+	return response, nil
+}
 
-	// Return the parsed response
-	return &Response{
-		Status:     resp.Status,
-		StatusCode: resp.StatusCode,
-		Body:       body,
-		Headers:    resp.Header,
-	}, nil
+// writeResponse copies the response body into w instead of buffering it, then closes it.
+// Reading fails once the request's context is done.
+func writeResponse(resp *http.Response, w io.Writer) (*Response, error) {
+	response := newResponse(resp)
+	written, err := io.Copy(w, resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, &responseWriteError{err: err}
+	}
+	response.ContentLength = written
+	return response, nil
+}
+
+// newStreamResponse wraps an HTTP response without reading its body, leaving it open in
+// RawBody. Closing RawBody also runs release, e.g. to cancel a per-request timeout
+// context; the body is closed on its own once ctx is done, so an abandoned stream does
+// not hold on to its connection and concurrency slot.
+func newStreamResponse(ctx context.Context, resp *http.Response, release func()) *Response {
+	body := &releasingBody{ReadCloser: resp.Body, release: release}
+	body.stop = context.AfterFunc(ctx, body.close) // The callback must not read stop, which is set after it is registered
+
+	response := newResponse(resp)
+	response.RawBody = body
+	return response
+}
+
+// releasingBody closes the underlying body and runs release exactly once, however often
+// and from however many goroutines it is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	stop    func() bool // Stops closing the body when the context is done
+	once    sync.Once
+	err     error
+}
+
+// Close stops watching the context, then closes the underlying body and releases
+// associated resources
+func (b *releasingBody) Close() error {
+	if b.stop != nil {
+		b.stop()
+	}
+	b.close()
+	return b.err
+}
+
+// close closes the underlying body and runs release once; it is also run by the
+// context's AfterFunc, so it must not touch stop
+func (b *releasingBody) close() {
+	b.once.Do(func() {
+		b.err = b.ReadCloser.Close()
+		b.release()
+	})
+}
+
+// limitedBody fails with ErrResponseTooLarge once more than remaining bytes are read
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+// Read reads from the underlying body, at most one byte past the limit to detect an overflow
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		return n, ErrResponseTooLarge
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
+
+// limitBody caps the response body at maxBytes, failing right away when the declared
+// Content-Length is already too large
+func limitBody(resp *http.Response, maxBytes int64) error {
+	if resp.ContentLength > maxBytes {
+		resp.Body.Close()
+		return fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrResponseTooLarge, resp.ContentLength, maxBytes)
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: maxBytes}
+	return nil
+}
+
+// timedBody fails reads with ErrBodyReadTimeout once its timer fires, which closes the
+// underlying body to abort a read in progress
+type timedBody struct {
+	io.ReadCloser
+	timer   *time.Timer
+	expired atomic.Bool
+}
+
+// Read reads from the underlying body, reporting failures after expiry as a timeout
+func (b *timedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && b.expired.Load() {
+		return n, ErrBodyReadTimeout
+	}
+	return n, err
+}
+
+// Close stops the timer and closes the underlying body
+func (b *timedBody) Close() error {
+	b.timer.Stop()
+	return b.ReadCloser.Close()
+}
+
+// limitBodyReadTime bounds the time spent reading the response body to timeout
+func limitBodyReadTime(resp *http.Response, timeout time.Duration) {
+	body := &timedBody{ReadCloser: resp.Body}
+	body.timer = time.AfterFunc(timeout, func() {
+		body.expired.Store(true)
+		body.ReadCloser.Close()
+	})
+	resp.Body = body
+}
+
+// decompressBody decodes a gzip or deflate body that the transport left compressed, which
+// happens when Accept-Encoding was set explicitly, so callers see the same decoded body
+// and headers as when Go negotiates compression itself
+func decompressBody(resp *http.Response) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return
+	}
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding != "gzip" && encoding != "x-gzip" && encoding != "deflate" {
+		return
+	}
+
+	resp.Body = &decodedBody{body: resp.Body, encoding: encoding}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// decodedBody decompresses a response body as it is read. The decoder is created on the
+// first read, so a streamed response is returned before any of its body arrives.
+type decodedBody struct {
+	body     io.ReadCloser
+	encoding string
+	reader   io.ReadCloser
+	err      error
+}
+
+// Read reads decompressed bytes, treating a body that ends before any data as empty
+func (b *decodedBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		if b.encoding == "deflate" {
+			b.reader, b.err = zlib.NewReader(b.body)
+		} else {
+			b.reader, b.err = gzip.NewReader(b.body)
+		}
+		if b.err != nil && b.err != io.EOF {
+			b.err = fmt.Errorf("decompressing %s response body: %w", b.encoding, b.err)
+		}
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+// Close closes the decoder and the underlying body
+func (b *decodedBody) Close() error {
+	if b.reader != nil {
+		b.reader.Close()
+	}
+	return b.body.Close()
 }
 
 // ParseJSON parses the HTTP response body as JSON into the provided interface
@@ -42,7 +278,269 @@ func (r *Response) ParseJSON(v interface{}) error {
 	return nil
 }
 
+// JSONOptions controls how ParseJSONWith decodes a JSON body
+type JSONOptions struct {
+	// DisallowUnknownFields fails on object keys that match no struct field, e.g. to catch
+	// API changes in tests
+	DisallowUnknownFields bool
+
+	// UseNumber decodes numbers stored in interface{} values as json.Number instead of
+	// float64, so large integers keep their precision
+	UseNumber bool
+}
+
+// ParseJSONWith parses the HTTP response body as a single JSON value into v with the given
+// decoding options; opts may be nil
+func (r *Response) ParseJSONWith(v interface{}, opts *JSONOptions) error {
+	if opts == nil {
+		opts = &JSONOptions{}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(r.Body))
+	if opts.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if opts.UseNumber {
+		decoder.UseNumber()
+	}
+
+	if err := decoder.Decode(v); err != nil {
+		if err == io.EOF {
+			err = ErrEmptyBody
+		}
+		return fmt.Errorf("error parsing JSON: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("error parsing JSON: unexpected data after the JSON value")
+	}
+	return nil
+}
+
+// ParseJSONStrict parses the HTTP response body as JSON into v, failing on object keys
+// that match no field of v
+func (r *Response) ParseJSONStrict(v interface{}) error {
+	return r.ParseJSONWith(v, &JSONOptions{DisallowUnknownFields: true})
+}
+
+// ParseXML parses the HTTP response body as XML into the provided interface
+func (r *Response) ParseXML(v interface{}) error {
+	if len(r.Body) == 0 {
+		return fmt.Errorf("error parsing XML: %w", ErrEmptyBody)
+	}
+	if err := xml.Unmarshal(r.Body, v); err != nil {
+		return fmt.Errorf("error parsing XML: %w", err)
+	}
+	return nil
+}
+
+// Decode decodes the body into v according to the response's Content-Type: JSON for
+// application/json and +json types, XML for application/xml, text/xml and +xml types,
+// URL-encoded forms into a *url.Values or *map[string]string, and MessagePack or protobuf
+// when Config.MsgpackCodec or Config.ProtoCodec is set. Other or missing types return
+// ErrUnsupportedContentType.
+func (r *Response) Decode(v interface{}) error {
+	contentType := r.ContentType()
+	if contentType == "" {
+		return fmt.Errorf("%w: no Content-Type header", ErrUnsupportedContentType)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return r.ParseJSON(v)
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return r.ParseXML(v)
+	case mediaType == "application/x-www-form-urlencoded":
+		return r.parseForm(v)
+	case isMsgpack(mediaType):
+		return r.ParseMsgpack(v)
+	case isProtobuf(mediaType):
+		return r.ParseProto(v)
+	}
+	return fmt.Errorf("%w: %q", ErrUnsupportedContentType, mediaType)
+}
+
+// parseForm decodes a URL-encoded body into a *url.Values, or into a *map[string]string
+// keeping the first value of each field
+func (r *Response) parseForm(v interface{}) error {
+	values, err := url.ParseQuery(string(r.Body))
+	if err != nil {
+		return fmt.Errorf("error parsing form: %w", err)
+	}
+
+	switch out := v.(type) {
+	case *url.Values:
+		*out = values
+	case *map[string]string:
+		*out = make(map[string]string, len(values))
+		for key := range values {
+			(*out)[key] = values.Get(key)
+		}
+	default:
+		return fmt.Errorf("error parsing form: cannot decode into %T", v)
+	}
+	return nil
+}
+
+// ParseMsgpack decodes a MessagePack response body into v with the request's
+// Config.MsgpackCodec. A body whose Content-Type is set to something other than
+// MessagePack is rejected.
+func (r *Response) ParseMsgpack(v interface{}) error {
+	if r.Config.MsgpackCodec == nil {
+		return fmt.Errorf("error parsing MessagePack: %w", ErrNoCodec)
+	}
+	if contentType := r.ContentType(); contentType != "" && !isMsgpack(contentType) {
+		return fmt.Errorf("error parsing MessagePack: unexpected Content-Type %q", contentType)
+	}
+	if len(r.Body) == 0 {
+		return fmt.Errorf("error parsing MessagePack: %w", ErrEmptyBody)
+	}
+	if err := r.Config.MsgpackCodec.Unmarshal(r.Body, v); err != nil {
+		return fmt.Errorf("error parsing MessagePack: %w", err)
+	}
+	return nil
+}
+
+// ParseProto decodes a protobuf response body into m, a protobuf message, with the
+// request's Config.ProtoCodec. A body whose Content-Type is set to something other than
+// protobuf is rejected. An empty body is a valid message with every field unset.
+func (r *Response) ParseProto(m interface{}) error {
+	if r.Config.ProtoCodec == nil {
+		return fmt.Errorf("error parsing protobuf: %w", ErrNoCodec)
+	}
+	if contentType := r.ContentType(); contentType != "" && !isProtobuf(contentType) {
+		return fmt.Errorf("error parsing protobuf: unexpected Content-Type %q", contentType)
+	}
+	if err := r.Config.ProtoCodec.Unmarshal(r.Body, m); err != nil {
+		return fmt.Errorf("error parsing protobuf: %w", err)
+	}
+	return nil
+}
+
+// EachJSONLine calls fn with every non-empty line of a newline-delimited JSON (NDJSON)
+// body, stopping at the first error fn returns. A streamed response is read incrementally
+// from RawBody, which the caller must still close; otherwise the buffered Body is used.
+func (r *Response) EachJSONLine(fn func(raw []byte) error) error {
+	reader := bufio.NewReader(r.bodyReader())
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if fnErr := fn(line); fnErr != nil {
+				return fnErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading JSON lines: %w", err)
+		}
+	}
+}
+
+// DecodeJSONStream decodes a single JSON value into v as the body is read, so a streamed
+// response is never held in memory as a whole; the buffered Body is used otherwise. The
+// caller must still close RawBody.
+func (r *Response) DecodeJSONStream(v interface{}) error {
+	if err := json.NewDecoder(r.bodyReader()).Decode(v); err != nil {
+		if err == io.EOF {
+			err = ErrEmptyBody
+		}
+		return fmt.Errorf("error parsing JSON: %w", err)
+	}
+	return nil
+}
+
+// JSONArrayElements decodes the elements of a JSON array body one at a time, yielding each
+// as soon as it has been read from a streamed response, so large arrays can be processed
+// without holding them in memory. Iteration stops after the first error. The caller must
+// still close RawBody, including when stopping early.
+//
+//	for item, err := range axios.JSONArrayElements[Item](resp) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func JSONArrayElements[T any](r *Response) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		decoder := json.NewDecoder(r.bodyReader())
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			if err == nil || err == io.EOF {
+				err = errors.New("body is not a JSON array")
+			}
+			yield(zero, fmt.Errorf("error parsing JSON: %w", err))
+			return
+		}
+
+		for decoder.More() {
+			var element T
+			if err := decoder.Decode(&element); err != nil {
+				yield(zero, fmt.Errorf("error parsing JSON: %w", err))
+				return
+			}
+			if !yield(element, nil) {
+				return
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			yield(zero, fmt.Errorf("error parsing JSON: %w", err))
+		}
+	}
+}
+
+// bodyReader returns the streamed RawBody if set, or else a reader over Body
+func (r *Response) bodyReader() io.Reader {
+	if r.RawBody != nil {
+		return r.RawBody
+	}
+	return bytes.NewReader(r.Body)
+}
+
 // IsSuccess checks if the response has a 2xx status code
 func (r *Response) IsSuccess() bool {
 	return r.StatusCode >= 200 && r.StatusCode < 300
 }
+
+// IsRedirect checks if the response has a 3xx status code
+func (r *Response) IsRedirect() bool {
+	return r.StatusCode >= 300 && r.StatusCode < 400
+}
+
+// IsError checks if the response has a 4xx or 5xx status code
+func (r *Response) IsError() bool {
+	return r.StatusCode >= 400
+}
+
+// IsClientError checks if the response has a 4xx status code
+func (r *Response) IsClientError() bool {
+	return r.StatusCode >= 400 && r.StatusCode < 500
+}
+
+// IsServerError checks if the response has a 5xx status code
+func (r *Response) IsServerError() bool {
+	return r.StatusCode >= 500 && r.StatusCode < 600
+}
+
+// Cookies parses the cookies set by the response's Set-Cookie headers
+func (r *Response) Cookies() []*http.Cookie {
+	return (&http.Response{Header: r.Headers}).Cookies()
+}
+
+// String returns the response body as a string
+func (r *Response) String() string {
+	return string(r.Body)
+}
+
+// Bytes returns the response body
+func (r *Response) Bytes() []byte {
+	return r.Body
+}
+
+// ContentType returns the Content-Type header of the response
+func (r *Response) ContentType() string {
+	return r.Headers.Get("Content-Type")
+}
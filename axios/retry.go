@@ -0,0 +1,277 @@
+package axios
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultRetryBaseDelay is used when RetryConfig.BaseDelay is not set
+	defaultRetryBaseDelay = 100 * time.Millisecond
+
+	// defaultMaxRetryDelay is used when RetryConfig.MaxDelay is not set
+	defaultMaxRetryDelay = 30 * time.Second
+
+	// defaultMaxRetryAfter is used when RetryConfig.MaxRetryAfter is not set
+	defaultMaxRetryAfter = 30 * time.Second
+)
+
+// JitterStrategy selects how the retry backoff is randomized
+type JitterStrategy int
+
+const (
+	// NoJitter waits exactly the exponential backoff
+	NoJitter JitterStrategy = iota
+
+	// FullJitter waits a random duration between zero and the backoff
+	FullJitter
+
+	// EqualJitter waits half the backoff plus a random duration up to the other half
+	EqualJitter
+)
+
+// RetryConfig controls automatic retries of failed requests. The wait between attempts
+// ends as soon as the request's context is done, returning the context's error.
+type RetryConfig struct {
+	MaxRetries int           // Number of retries after the first attempt
+	BaseDelay  time.Duration // Delay before the first retry, doubled on every attempt (default 100ms)
+	MaxDelay   time.Duration // Cap on the doubled backoff, before jitter (default 30s)
+
+	// MaxRetryAfter caps the delay requested by a Retry-After header (default 30s)
+	MaxRetryAfter time.Duration
+
+	// Jitter randomizes the backoff so that many clients failing together don't retry
+	// in lockstep. Delays requested by a Retry-After header are never randomized.
+	Jitter JitterStrategy
+
+	// Rand returns the random numbers in [0, 1) used for jitter. Defaults to math/rand/v2's
+	// Float64; set it, e.g. to a seeded rand.Rand's Float64, for reproducible delays. It
+	// must be safe for concurrent use if the config is shared by concurrent requests.
+	Rand func() float64
+
+	// RetryOn decides whether an attempt should be retried. Status errors are passed
+	// as a *RequestError with a nil Response. Defaults to DefaultRetryOn.
+	RetryOn func(*Response, error) bool
+
+	// RetryStatusCodes, when set, lists the only statuses that are retried (e.g. 502, 503
+	// and 504); status errors are then decided by the list instead of RetryOn
+	RetryStatusCodes []int
+
+	// RetryMethods lists the methods that may be retried. Defaults to the idempotent
+	// methods GET, HEAD, OPTIONS, TRACE, PUT and DELETE, so a failed POST or PATCH is not
+	// sent twice. Requests with an idempotency key are retried whatever their method.
+	RetryMethods []string
+}
+
+// DefaultRetryOn retries network errors and timeouts, 429 Too Many Requests and 5xx status
+// errors. Failures that another attempt would repeat, such as interceptor errors, invalid
+// configs, oversized responses and requests refused by an open circuit breaker, are not
+// retried, nor are cancellation and context deadlines.
+func DefaultRetryOn(resp *Response, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.StatusCode == http.StatusTooManyRequests || reqErr.StatusCode >= 500
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return transientError(err)
+}
+
+// transientError reports whether err is a connection failure or timeout, which another
+// attempt may not run into
+func transientError(err error) bool {
+	if errors.Is(err, ErrBodyReadTimeout) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true // Timed out or cut off mid-response
+	}
+
+	// http.Client wraps every failure, e.g. a redirect policy's, in a *url.Error, which
+	// is a net.Error itself; only a network error underneath it counts
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// idempotentMethods are the methods retried when RetryConfig.RetryMethods is not set
+var idempotentMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete,
+}
+
+// shouldRetry reports whether the attempt's outcome warrants another attempt
+func (rc *RetryConfig) shouldRetry(ctx context.Context, config Config, resp *Response, err error) bool {
+	if ctx.Err() != nil || !rc.retriesMethod(config) {
+		return false
+	}
+	var writeErr *responseWriteError
+	if errors.As(err, &writeErr) {
+		return false // The writer may already hold part of the body
+	}
+
+	var reqErr *RequestError
+	if len(rc.RetryStatusCodes) > 0 && errors.As(err, &reqErr) {
+		return slices.Contains(rc.RetryStatusCodes, reqErr.StatusCode)
+	}
+
+	retryOn := rc.RetryOn
+	if retryOn == nil {
+		retryOn = DefaultRetryOn
+	}
+	return retryOn(resp, err)
+}
+
+// retriesMethod reports whether requests with the config's method may be retried
+func (rc *RetryConfig) retriesMethod(config Config) bool {
+	if config.IdempotencyKey != "" {
+		return true
+	}
+
+	method := config.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	methods := rc.RetryMethods
+	if methods == nil {
+		methods = idempotentMethods
+	}
+	return slices.ContainsFunc(methods, func(m string) bool { return strings.EqualFold(m, method) })
+}
+
+// delay returns how long to wait before retrying the given attempt. A Retry-After
+// header on the failed response takes precedence over the exponential backoff.
+func (rc *RetryConfig) delay(attempt int, resp *Response, err error) time.Duration {
+	if wait, ok := retryAfter(resp, err); ok {
+		maxWait := rc.MaxRetryAfter
+		if maxWait <= 0 {
+			maxWait = defaultMaxRetryAfter
+		}
+		return min(wait, maxWait)
+	}
+	return rc.jitter(rc.backoff(attempt))
+}
+
+// jitter randomizes delay according to the configured strategy
+func (rc *RetryConfig) jitter(delay time.Duration) time.Duration {
+	random := rc.Rand
+	if random == nil {
+		random = mathrand.Float64
+	}
+
+	switch rc.Jitter {
+	case FullJitter:
+		return time.Duration(random() * float64(delay))
+	case EqualJitter:
+		half := delay / 2
+		return half + time.Duration(random()*float64(delay-half))
+	default:
+		return delay
+	}
+}
+
+// backoff returns the exponential delay before the retry following the given attempt
+func (rc *RetryConfig) backoff(attempt int) time.Duration {
+	delay := rc.BaseDelay
+	if delay <= 0 {
+		delay = defaultRetryBaseDelay
+	}
+	maxDelay := rc.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+
+	// Stop doubling at the cap, so large attempt counts cannot overflow
+	for ; attempt > 0 && delay < maxDelay; attempt-- {
+		if delay > maxDelay/2 {
+			return maxDelay
+		}
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// retryAfter extracts the Retry-After delay from a failed attempt, if present
+func retryAfter(resp *Response, err error) (time.Duration, bool) {
+	var headers http.Header
+	var reqErr *RequestError
+	switch {
+	case errors.As(err, &reqErr):
+		headers = reqErr.Headers
+	case resp != nil:
+		headers = resp.Headers
+	}
+	return parseRetryAfter(headers.Get("Retry-After"))
+}
+
+// parseRetryAfter parses a Retry-After value given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for the given duration, returning early if the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// NewIdempotencyKey returns a random UUID (version 4) for use as Config.IdempotencyKey
+func NewIdempotencyKey() string {
+	return newUUID()
+}
+
+// newUUID returns a random UUID (version 4)
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])         // Never returns an error
+	b[6] = b[6]&0x0f | 0x40 // Version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// idempotencyKey returns the key to send with the request, generating one if asked to
+func idempotencyKey(config Config) string {
+	if config.IdempotencyKey != "" || !config.AutoIdempotencyKey {
+		return config.IdempotencyKey
+	}
+	if config.Method == http.MethodPost || config.Method == http.MethodPatch {
+		return NewIdempotencyKey()
+	}
+	return ""
+}
@@ -0,0 +1,185 @@
+package axios
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultReconnectDelay is used when EventStreamOptions.ReconnectDelay is not set
+const defaultReconnectDelay = 3 * time.Second
+
+// Event is a single server-sent event
+type Event struct {
+	ID    string // The last event ID seen on the stream, sent back as Last-Event-ID on reconnect
+	Event string // The event type; "message" unless the server named it
+	Data  string // The data lines joined with newlines
+}
+
+// EventStreamOptions controls reconnection of an event stream
+type EventStreamOptions struct {
+	Reconnect bool // Reconnect with Last-Event-ID when the stream ends or breaks
+
+	// ReconnectDelay is the wait before reconnecting (default 3s); a retry field sent
+	// by the server replaces it
+	ReconnectDelay time.Duration
+}
+
+// StreamEvents opens a text/event-stream and delivers its events on the returned channel,
+// which is closed when the stream ends, ctx is done or, when reconnecting, the server
+// rejects a reconnection. Only the initial request's error is returned; opts may be nil.
+// Timeouts from the config or client bound the whole stream, so long-lived streams
+// should be opened without one.
+func (c *Client) StreamEvents(ctx context.Context, config Config, opts *EventStreamOptions) (<-chan Event, error) {
+	if opts == nil {
+		opts = &EventStreamOptions{}
+	}
+	delay := opts.ReconnectDelay
+	if delay <= 0 {
+		delay = defaultReconnectDelay
+	}
+
+	// Copy the headers so reconnects can add Last-Event-ID without touching the caller's config
+	config.Headers = config.Headers.Clone()
+	if config.Headers == nil {
+		config.Headers = http.Header{}
+	}
+	if config.Headers.Get("Accept") == "" {
+		config.Headers.Set("Accept", "text/event-stream")
+	}
+	config.Headers.Set("Cache-Control", "no-cache")
+
+	resp, err := c.RequestStream(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+
+		stream := &eventStream{events: events, retry: delay}
+		for {
+			stream.read(ctx, resp.RawBody)
+			resp.RawBody.Close()
+			if !opts.Reconnect {
+				return
+			}
+
+			// Reconnect after network failures; a rejected reconnection ends the stream
+			for {
+				if sleepContext(ctx, stream.retry) != nil {
+					return
+				}
+				if stream.lastID != "" {
+					config.Headers.Set("Last-Event-ID", stream.lastID)
+				}
+				resp, err = c.RequestStream(ctx, config)
+				if err == nil {
+					break
+				}
+				if _, isStatus := IsStatusError(err); isStatus {
+					return
+				}
+			}
+
+			// 204 No Content tells the client to stop reconnecting
+			if resp.StatusCode == http.StatusNoContent {
+				resp.RawBody.Close()
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// eventStream parses the text/event-stream format, keeping the state that lasts across reconnects
+type eventStream struct {
+	events chan<- Event
+	lastID string
+	retry  time.Duration
+}
+
+// read dispatches the events in body until it ends, fails or ctx is done
+func (s *eventStream) read(ctx context.Context, body io.Reader) {
+	lines := &lineReader{reader: bufio.NewReader(body)}
+	var eventType string
+	var data []string
+
+	for {
+		line, err := lines.readLine()
+		if err != nil {
+			return // An incomplete trailing event is discarded
+		}
+
+		// A blank line dispatches the event that has been built up
+		if line == "" {
+			if data != nil {
+				event := Event{ID: s.lastID, Event: eventType, Data: strings.Join(data, "\n")}
+				if event.Event == "" {
+					event.Event = "message"
+				}
+				select {
+				case s.events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			eventType, data = "", nil
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.lastID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+		// Lines starting with a colon are comments, and unknown fields are ignored
+	}
+}
+
+// lineReader splits an event stream into lines, which may end in "\r\n", "\n" or a lone "\r"
+type lineReader struct {
+	reader  *bufio.Reader
+	afterCR bool // The last line ended in "\r", so a "\n" right after it belongs to that line
+}
+
+// readLine returns the next line without its line ending. A line ending in "\r" is returned
+// straight away rather than after waiting to see whether a "\n" follows.
+func (r *lineReader) readLine() (string, error) {
+	var line []byte
+	for {
+		b, err := r.reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		afterCR := r.afterCR
+		r.afterCR = false
+
+		switch {
+		case b == '\n' && afterCR:
+			continue // The rest of a "\r\n"
+		case b == '\n':
+			return string(line), nil
+		case b == '\r':
+			r.afterCR = true
+			return string(line), nil
+		}
+		line = append(line, b)
+	}
+}
@@ -0,0 +1,57 @@
+package axios
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// LoadClientCert loads a PEM-encoded certificate and private key from files for mutual TLS,
+// for use as TransportOptions.ClientCert
+func LoadClientCert(certFile, keyFile string) (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading client certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// LoadCertPool returns the system certificate pool extended with the given PEM-encoded
+// CA certificates, for use as TransportOptions.RootCAs
+func LoadCertPool(pemCerts ...[]byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	for _, pemCert := range pemCerts {
+		if !pool.AppendCertsFromPEM(pemCert) {
+			return nil, errors.New("no valid PEM certificates found")
+		}
+	}
+	return pool, nil
+}
+
+// buildTLSConfig derives the transport TLS configuration from the options,
+// returning nil when the defaults should be used
+func buildTLSConfig(opts *TransportOptions) *tls.Config {
+	if opts.TLSConfig == nil && !opts.InsecureSkipVerify && opts.RootCAs == nil && opts.ClientCert == nil {
+		return nil
+	}
+
+	config := &tls.Config{}
+	if opts.TLSConfig != nil {
+		config = opts.TLSConfig.Clone()
+	}
+	if opts.RootCAs != nil {
+		config.RootCAs = opts.RootCAs
+	}
+	if opts.ClientCert != nil {
+		config.Certificates = append(config.Certificates, *opts.ClientCert)
+	}
+	if opts.InsecureSkipVerify {
+		config.InsecureSkipVerify = true
+	}
+	return config
+}
@@ -0,0 +1,87 @@
+package axios
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timings breaks down where the time of a traced request went. Phases that did not
+// happen, e.g. DNS and connect on a reused connection, are zero.
+type Timings struct {
+	DNSLookup       time.Duration
+	Connect         time.Duration // TCP connect, excluding DNS
+	TLSHandshake    time.Duration
+	TimeToFirstByte time.Duration // From asking for a connection until the first response byte
+	ConnReused      bool          // Whether an idle keep-alive connection was reused
+}
+
+// tracer records connection phase timings from httptrace hooks, which may run on other goroutines
+type tracer struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	timings      Timings
+}
+
+// withTrace installs a tracer on ctx
+func withTrace(ctx context.Context) (context.Context, *tracer) {
+	t := &tracer{}
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GetConn: func(string) {
+			t.record(func() { t.start = time.Now() })
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.record(func() { t.timings.ConnReused = info.Reused })
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.record(func() { t.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.record(func() { t.timings.DNSLookup = time.Since(t.dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			t.record(func() {
+				// Dialing may race several addresses; time from the first attempt
+				if t.connectStart.IsZero() {
+					t.connectStart = time.Now()
+				}
+			})
+		},
+		ConnectDone: func(_, _ string, err error) {
+			t.record(func() {
+				if err == nil && t.timings.Connect == 0 {
+					t.timings.Connect = time.Since(t.connectStart)
+				}
+			})
+		},
+		TLSHandshakeStart: func() {
+			t.record(func() { t.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.record(func() { t.timings.TLSHandshake = time.Since(t.tlsStart) })
+		},
+		GotFirstResponseByte: func() {
+			t.record(func() { t.timings.TimeToFirstByte = time.Since(t.start) })
+		},
+	}), t
+}
+
+// record applies an update to the timings under the lock
+func (t *tracer) record(update func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	update()
+}
+
+// snapshot returns a copy of the timings recorded so far
+func (t *tracer) snapshot() *Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := t.timings
+	return &timings
+}
@@ -0,0 +1,125 @@
+package axios
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// expandPath replaces {name} placeholders in rawURL with the matching path params, each
+// escaped as a single path segment. A placeholder without a value is an error, even when
+// no path params are given at all.
+func expandPath(rawURL string, pathParams map[string]string) (string, error) {
+	if !strings.Contains(rawURL, "{") {
+		return rawURL, nil
+	}
+
+	var expanded strings.Builder
+	rest := rawURL
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated path parameter in %q", rawURL)
+		}
+		end += start
+
+		name := rest[start+1 : end]
+		value, ok := pathParams[name]
+		if !ok {
+			return "", fmt.Errorf("missing path parameter %q in %q", name, rawURL)
+		}
+		expanded.WriteString(rest[:start])
+		expanded.WriteString(url.PathEscape(value))
+		rest = rest[end+1:]
+	}
+	expanded.WriteString(rest)
+
+	return expanded.String(), nil
+}
+
+// resolveURL resolves rawURL against baseURL when rawURL is relative.
+// Like axios, the base path is kept: "https://api.example.com/v1" + "/posts/1"
+// resolves to "https://api.example.com/v1/posts/1". Absolute URLs are returned as-is.
+func resolveURL(baseURL, rawURL string) (string, error) {
+	if baseURL == "" {
+		return rawURL, nil
+	}
+
+	ref, err := url.Parse(rawURL)
+	if err != nil {
+		return "", invalidURL(rawURL, err)
+	}
+	if ref.IsAbs() {
+		return rawURL, nil
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("base URL: %w", invalidURL(baseURL, err))
+	}
+
+	// Treat the base path as a directory so relative paths are appended to it
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	ref.Path = strings.TrimPrefix(ref.Path, "/")
+	ref.RawPath = strings.TrimPrefix(ref.RawPath, "/") // Keeps escapes such as %2F intact
+
+	return base.ResolveReference(ref).String(), nil
+}
+
+// buildURL encodes params onto rawURL, preserving any query string already present.
+// Params take precedence over query parameters of the same name in rawURL.
+func buildURL(rawURL string, params map[string]string) (string, error) {
+	if len(params) == 0 {
+		return rawURL, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", invalidURL(rawURL, err)
+	}
+
+	// Combine existing query parameters with the configured ones
+	query := u.Query()
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// validateURL checks that the final request URL is absolute, so misconfigurations are
+// reported clearly instead of failing deep inside net/http
+func validateURL(rawURL string) error {
+	if strings.TrimSpace(rawURL) == "" {
+		return invalidURL(rawURL, "URL is empty")
+	}
+
+	u, err := url.Parse(rawURL)
+	switch {
+	case err != nil:
+		return invalidURL(rawURL, err)
+	case u.Scheme == "":
+		return invalidURL(rawURL, "missing scheme such as https://, or a BaseURL to resolve it against")
+	case (strings.EqualFold(u.Scheme, "http") || strings.EqualFold(u.Scheme, "https")) && u.Host == "":
+		return invalidURL(rawURL, "missing host")
+	}
+	return nil
+}
+
+// invalidURL builds an ErrInvalidURL naming the offending value. The reason is either a
+// message or a parse error, whose *url.Error wrapper would repeat the URL.
+func invalidURL(rawURL string, reason interface{}) error {
+	var urlErr *url.Error
+	if err, ok := reason.(error); ok && errors.As(err, &urlErr) {
+		reason = urlErr.Err
+	}
+	return fmt.Errorf("%w %q: %v", ErrInvalidURL, rawURL, reason)
+}
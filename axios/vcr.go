@@ -0,0 +1,303 @@
+package axios
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"unicode/utf8"
+)
+
+// redactedValue replaces the values of redacted headers in cassettes and debug dumps
+const redactedValue = "REDACTED"
+
+// defaultRedactedHeaders are the credential headers hidden unless configured otherwise
+var defaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// ErrInteractionNotFound is returned when replaying a request that has no unused recorded interaction
+var ErrInteractionNotFound = errors.New("no recorded interaction matches request")
+
+// RecorderMode selects whether a Recorder records real interactions or replays recorded ones
+type RecorderMode int
+
+const (
+	// ModeRecordOnce replays the cassette if its file exists and records a new one otherwise
+	ModeRecordOnce RecorderMode = iota
+
+	// ModeRecord always sends requests and records them, replacing any existing cassette
+	ModeRecord
+
+	// ModeReplay only replays the cassette and fails if its file does not exist
+	ModeReplay
+)
+
+// Cassette is a recorded sequence of HTTP interactions, stored as JSON
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a recorded request
+type CassetteRequest struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"` // "base64" for bodies that are not valid UTF-8
+}
+
+// CassetteResponse is a recorded response
+type CassetteResponse struct {
+	Status       string      `json:"status"`
+	StatusCode   int         `json:"status_code"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"` // "base64" for bodies that are not valid UTF-8
+}
+
+// RecorderOptions controls how a Recorder records and replays
+type RecorderOptions struct {
+	Mode RecorderMode // Defaults to ModeRecordOnce
+
+	// Transport sends the real requests while recording. Defaults to the transport
+	// NewClient would use.
+	Transport http.RoundTripper
+
+	// RedactHeaders lists request and response headers whose values are replaced before
+	// the cassette is written. Defaults to Authorization, Proxy-Authorization, Cookie and
+	// Set-Cookie; set it to an empty, non-nil slice to record every header as-is.
+	RedactHeaders []string
+
+	// Match decides whether a recorded request answers an incoming one. Defaults to
+	// comparing the method and full URL.
+	Match func(req *http.Request, recorded CassetteRequest) bool
+}
+
+// Recorder is an http.RoundTripper that records real interactions to a cassette file and
+// replays them offline afterwards, for integration tests. Install it with
+// Client.SetTransport. It is safe for concurrent use.
+type Recorder struct {
+	path      string
+	recording bool
+	transport http.RoundTripper
+	redact    []string
+	match     func(*http.Request, CassetteRequest) bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool // Recorded interactions already replayed
+}
+
+// NewRecorder creates a Recorder for the cassette at path; opts may be nil. When
+// replaying, the cassette is loaded right away. Recorded interactions are only written
+// by Save.
+func NewRecorder(path string, opts *RecorderOptions) (*Recorder, error) {
+	if opts == nil {
+		opts = &RecorderOptions{}
+	}
+
+	r := &Recorder{
+		path:      path,
+		transport: opts.Transport,
+		redact:    opts.RedactHeaders,
+		match:     opts.Match,
+	}
+	if r.transport == nil {
+		r.transport = defaultTransport(nil)
+	}
+	if r.redact == nil {
+		r.redact = defaultRedactedHeaders
+	}
+	if r.match == nil {
+		r.match = matchMethodAndURL
+	}
+
+	switch opts.Mode {
+	case ModeRecord:
+		r.recording = true
+		return r, nil
+	case ModeRecordOnce:
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			r.recording = true
+			return r, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Recording reports whether the recorder sends real requests rather than replaying
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// RoundTrip records or replays req depending on the recorder's mode
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.recording {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// record sends req through the real transport and appends the interaction to the cassette.
+// A request with a body is sent as a copy carrying the buffered body, leaving req unmodified.
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	recorded := CassetteRequest{Method: req.Method, URL: req.URL.String(), Headers: redactHeaders(req.Header, r.redact)}
+	outgoing := req
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading request body for cassette: %w", err)
+		}
+		recorded.Body, recorded.BodyEncoding = encodeCassetteBody(body)
+		outgoing = req.Clone(req.Context())
+		outgoing.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	resp.Request = req
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body for cassette: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: CassetteResponse{
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			Headers:    redactHeaders(resp.Header, r.redact),
+		},
+	}
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeCassetteBody(body)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers req with the first unused recorded interaction that matches it
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	var recorded *CassetteResponse
+	for i := range r.cassette.Interactions {
+		if !r.used[i] && r.match(req, r.cassette.Interactions[i].Request) {
+			r.used[i] = true
+			recorded = &r.cassette.Interactions[i].Response
+			break
+		}
+	}
+	r.mu.Unlock()
+
+	if recorded == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, req.Method, req.URL)
+	}
+	body, err := decodeCassetteBody(recorded.Body, recorded.BodyEncoding)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return &http.Response{
+		Status:        recorded.Status,
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Save writes the recorded interactions to the cassette file, creating its directory if
+// needed. It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if !r.recording {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("encoding cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+	return nil
+}
+
+// redactHeaders returns a copy of header with the values of the named headers replaced
+func redactHeaders(header http.Header, names []string) http.Header {
+	header = header.Clone()
+	for _, name := range names {
+		if len(header.Values(name)) > 0 {
+			header[http.CanonicalHeaderKey(name)] = []string{redactedValue}
+		}
+	}
+	return header
+}
+
+// matchMethodAndURL is the default Recorder matcher
+func matchMethodAndURL(req *http.Request, recorded CassetteRequest) bool {
+	return req.Method == recorded.Method && req.URL.String() == recorded.URL
+}
+
+// encodeCassetteBody stores text bodies as-is and other bodies as base64
+func encodeCassetteBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+// decodeCassetteBody reverses encodeCassetteBody
+func decodeCassetteBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			return nil, fmt.Errorf("decoding cassette body: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unknown cassette body encoding %q", encoding)
+	}
+}
@@ -0,0 +1,7 @@
+package axios
+
+// Version is the current release of the go-axios package
+const Version = "1.2.0"
+
+// DefaultUserAgent is sent with every request unless overridden by Config.UserAgent or a User-Agent header
+const DefaultUserAgent = "go-axios/" + Version
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
 	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
@@ -33,6 +34,15 @@ func main() {
 
 	// Example of a PUT request
 	executePutRequest(client)
+
+	// Example of a PATCH request
+	executePatchRequest(client)
+
+	// Example of a HEAD request
+	executeHeadRequest(client)
+
+	// Example of an OPTIONS request
+	executeOptionsRequest(client)
 }
 
 // GET Request Example
@@ -73,19 +83,11 @@ func executePostRequest(client *axios.Client) {
 		UserID: 1,
 	}
 
-	// Convert the payload to JSON
-	data, err := json.Marshal(payload)
-	if err != nil {
-		log.Printf("Failed to marshal payload: %v", err)
-		return
-	}
-
-	// Configuration for the POST request
+	// Configuration for the POST request; the payload is marshaled to JSON by the client
 	reqConfig := axios.Config{
-		Method:  "POST",
-		URL:     "https://jsonplaceholder.typicode.com/posts",
-		Body:    data,
-		Headers: map[string][]string{"Content-Type": {"application/json"}},
+		Method:   "POST",
+		URL:      "https://jsonplaceholder.typicode.com/posts",
+		JSONBody: payload,
 	}
 
 	// Create a context with a timeout of 5 seconds
@@ -141,19 +143,11 @@ func executePutRequest(client *axios.Client) {
 		UserID: 1,
 	}
 
-	// Convert the payload to JSON
-	data, err := json.Marshal(payload)
-	if err != nil {
-		log.Printf("Failed to marshal payload: %v", err)
-		return
-	}
-
-	// Configuration for the PUT request
+	// Configuration for the PUT request; the payload is marshaled to JSON by the client
 	reqConfig := axios.Config{
-		Method:  "PUT",
-		URL:     "https://jsonplaceholder.typicode.com/posts/1",
-		Body:    data,
-		Headers: map[string][]string{"Content-Type": {"application/json"}},
+		Method:   "PUT",
+		URL:      "https://jsonplaceholder.typicode.com/posts/1",
+		JSONBody: payload,
 	}
 
 	// Create a context with a timeout of 5 seconds
@@ -176,3 +170,64 @@ func executePutRequest(client *axios.Client) {
 
 	fmt.Printf("PUT Response: %+v\n\n", updatedPost)
 }
+
+// PATCH Request Example
+func executePatchRequest(client *axios.Client) {
+	// Only the fields being changed are sent; the response is decoded into Result
+	var patchedPost Post
+	reqConfig := axios.Config{
+		Method:   "PATCH",
+		URL:      "https://jsonplaceholder.typicode.com/posts/1",
+		JSONBody: map[string]string{"title": "Patched Title"},
+		Result:   &patchedPost,
+	}
+
+	// Create a context with a timeout of 5 seconds
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Send the request
+	if _, err := client.CancelableRequest(ctx, reqConfig); err != nil {
+		log.Printf("PATCH request failed: %v", err)
+		return
+	}
+
+	fmt.Printf("PATCH Response: %+v\n\n", patchedPost)
+}
+
+// HEAD Request Example
+func executeHeadRequest(client *axios.Client) {
+	// Create a context with a timeout of 5 seconds
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A HEAD response has no body, but carries the same headers a GET would
+	resp, err := client.Head(ctx, "https://jsonplaceholder.typicode.com/posts/1")
+	if err != nil {
+		log.Printf("HEAD request failed: %v", err)
+		return
+	}
+
+	fmt.Printf("HEAD Response Status: %s, Content-Type: %s\n\n", resp.Status, resp.Headers.Get("Content-Type"))
+}
+
+// OPTIONS Request Example
+func executeOptionsRequest(client *axios.Client) {
+	// Create a context with a timeout of 5 seconds
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Inspect the CORS preflight answer for a cross-origin PATCH
+	resp, err := client.Options(ctx, "https://jsonplaceholder.typicode.com/posts/1", axios.Config{
+		Headers: http.Header{
+			"Origin":                        {"https://example.com"},
+			"Access-Control-Request-Method": {"PATCH"},
+		},
+	})
+	if err != nil {
+		log.Printf("OPTIONS request failed: %v", err)
+		return
+	}
+
+	fmt.Printf("OPTIONS Response Status: %s, Allowed Methods: %s\n\n", resp.Status, resp.Headers.Get("Access-Control-Allow-Methods"))
+}
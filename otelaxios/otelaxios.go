@@ -0,0 +1,111 @@
+// Package otelaxios adds OpenTelemetry tracing to go-axios clients. Instrument wraps each
+// request in a client span and injects the trace context into its headers. It is a
+// separate Go module, so programs that only import axios never pull in OpenTelemetry.
+package otelaxios
+
+import (
+	"fmt"
+	"net/http"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer created by this package
+const instrumentationName = "github.com/MOHAMMADmiZAN/go-axios/otelaxios"
+
+// Option configures the tracing set up by Instrument
+type Option func(*config)
+
+// config holds the providers used by Instrument
+type config struct {
+	tracerProvider trace.TracerProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// WithTracerProvider sets the provider spans are created with; the global provider is used
+// by default, which is a no-op until one is registered
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithPropagators sets how trace context is injected into request headers; the global
+// propagators are used by default
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+// Instrument wraps the client's transport so every round trip, including retries and
+// redirects, runs in a client span and carries trace context headers such as traceparent.
+// It must not be called concurrently with requests.
+func Instrument(client *axios.Client, opts ...Option) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	base := client.HTTPClient().Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.SetTransport(&transport{
+		base:        base,
+		tracer:      cfg.tracerProvider.Tracer(instrumentationName, trace.WithInstrumentationVersion(axios.Version)),
+		propagators: cfg.propagators,
+	})
+}
+
+// transport starts a span around each round trip of the wrapped transport
+type transport struct {
+	base        http.RoundTripper
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+}
+
+// RoundTrip sends the request in a client span, recording standard HTTP attributes
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", redactedURL(req)),
+			attribute.String("server.address", req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	// Inject trace context into a copy so the caller's request is left untouched
+	req = req.Clone(ctx)
+	t.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", resp.StatusCode))
+	}
+	return resp, nil
+}
+
+// redactedURL returns the request URL without any user credentials
+func redactedURL(req *http.Request) string {
+	u := *req.URL
+	u.User = nil
+	return u.String()
+}
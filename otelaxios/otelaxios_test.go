@@ -0,0 +1,60 @@
+package otelaxios_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/MOHAMMADmiZAN/go-axios/otelaxios"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestOpenTelemetryInstrument verifies that requests run in client spans and propagate trace context.
+func TestOpenTelemetryInstrument(t *testing.T) {
+	// Mock server that reports the traceparent header it received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(r.Header.Get("traceparent")))
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	otelaxios.Instrument(client,
+		otelaxios.WithTracerProvider(provider),
+		otelaxios.WithPropagators(propagation.TraceContext{}),
+	)
+
+	resp, err := client.Get(context.TODO(), server.URL+"/ok")
+	assert.NoError(t, err, "Request should succeed")
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1, "One span should be recorded per request") {
+		span := spans[0]
+		assert.Equal(t, "HTTP GET", span.Name(), "Span should be named after the method")
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind(), "Span should be a client span")
+		assert.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", 200), "Status code should be recorded")
+		assert.Contains(t, resp.String(), span.SpanContext().TraceID().String(), "traceparent should carry the span's trace ID")
+	}
+
+	// Error statuses mark the span as failed
+	_, err = client.Get(context.TODO(), server.URL+"/missing")
+	assert.Error(t, err, "404 should return an error")
+	spans = recorder.Ended()
+	if assert.Len(t, spans, 2, "Failed request should also be traced") {
+		assert.Equal(t, codes.Error, spans[1].Status().Code, "404 span should have an error status")
+	}
+}
@@ -0,0 +1,85 @@
+// Package promaxios records Prometheus metrics for go-axios clients: a request counter
+// labelled by method, host and status class, and a latency histogram. It has a go.mod of
+// its own, keeping the Prometheus client library out of the core module's requirements.
+package promaxios
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds the collectors that Instrument registers
+type Metrics struct {
+	Requests *prometheus.CounterVec   // go_axios_requests_total by method, host and status class
+	Duration *prometheus.HistogramVec // go_axios_request_duration_seconds by method and host
+}
+
+// NewMetrics creates the request counter and latency histogram without registering them
+func NewMetrics() *Metrics {
+	return &Metrics{
+		Requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "go_axios_requests_total",
+			Help: "HTTP requests sent, by method, host and status class (2xx, 4xx, ... or error).",
+		}, []string{"method", "host", "status"}),
+		Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "go_axios_request_duration_seconds",
+			Help:    "Time until response headers were received, by method and host.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "host"}),
+	}
+}
+
+// Instrument registers a new set of metrics with registerer and records every round trip
+// of the client, including retries and redirects. It must not be called concurrently with requests.
+func Instrument(client *axios.Client, registerer prometheus.Registerer) (*Metrics, error) {
+	metrics := NewMetrics()
+	if err := registerer.Register(metrics.Requests); err != nil {
+		return nil, fmt.Errorf("registering request counter: %w", err)
+	}
+	if err := registerer.Register(metrics.Duration); err != nil {
+		registerer.Unregister(metrics.Requests)
+		return nil, fmt.Errorf("registering duration histogram: %w", err)
+	}
+
+	metrics.Wrap(client)
+	return metrics, nil
+}
+
+// Wrap records the client's requests into already registered metrics, e.g. to share
+// one set of metrics between several clients
+func (m *Metrics) Wrap(client *axios.Client) {
+	base := client.HTTPClient().Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.SetTransport(&transport{base: base, metrics: m})
+}
+
+// transport observes each round trip of the wrapped transport
+type transport struct {
+	base    http.RoundTripper
+	metrics *Metrics
+}
+
+// RoundTrip sends the request and records its outcome and latency
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	host := req.URL.Host
+	t.metrics.Duration.WithLabelValues(req.Method, host).Observe(time.Since(start).Seconds())
+	t.metrics.Requests.WithLabelValues(req.Method, host, statusClass(resp, err)).Inc()
+	return resp, err
+}
+
+// statusClass buckets a round trip's outcome, keeping label cardinality low
+func statusClass(resp *http.Response, err error) string {
+	if err != nil {
+		return "error"
+	}
+	return fmt.Sprintf("%dxx", resp.StatusCode/100)
+}
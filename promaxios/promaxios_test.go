@@ -0,0 +1,43 @@
+package promaxios_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/MOHAMMADmiZAN/go-axios/promaxios"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPrometheusInstrument verifies that requests are counted by status class and timed.
+func TestPrometheusInstrument(t *testing.T) {
+	// Mock server that fails requests to /missing
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	host := server.Listener.Addr().String()
+
+	registry := prometheus.NewRegistry()
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	metrics, err := promaxios.Instrument(client, registry)
+	assert.NoError(t, err, "Registering metrics should succeed")
+
+	client.Get(context.TODO(), server.URL)
+	client.Get(context.TODO(), server.URL)
+	client.Post(context.TODO(), server.URL+"/missing", nil)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.Requests.WithLabelValues("GET", host, "2xx")), "Successful GETs should be counted")
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.Requests.WithLabelValues("POST", host, "4xx")), "Failed POST should be counted")
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.Duration), "Latency should be observed per method and host")
+
+	// Registering a second set of metrics with the same registry fails
+	_, err = promaxios.Instrument(axios.NewClient(axios.Config{}, nil), registry)
+	assert.Error(t, err, "Duplicate registration should return an error")
+}
@@ -0,0 +1,22 @@
+module github.com/MOHAMMADmiZAN/go-axios/protoaxios
+
+go 1.23.1
+
+require (
+	github.com/MOHAMMADmiZAN/go-axios v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.10.0
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.34.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Builds against the go-axios checkout this module lives in
+replace github.com/MOHAMMADmiZAN/go-axios => ../
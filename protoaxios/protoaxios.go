@@ -0,0 +1,36 @@
+// Package protoaxios provides the protobuf Codec for go-axios clients, e.g. for
+// gRPC-gateway services that accept application/x-protobuf. Set Codec{} as
+// Config.ProtoCodec to send Config.ProtoBody messages and decode them with
+// Response.ParseProto. It is a module of its own, so only programs that import it depend
+// on the protobuf runtime.
+package protoaxios
+
+import (
+	"fmt"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec marshals and unmarshals proto.Message values
+type Codec struct{}
+
+var _ axios.Codec = Codec{}
+
+// Marshal encodes v, which must be a proto.Message
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("cannot marshal %T: not a proto.Message", v)
+	}
+	return proto.Marshal(m)
+}
+
+// Unmarshal decodes data into v, which must be a proto.Message
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal into %T: not a proto.Message", v)
+	}
+	return proto.Unmarshal(data, m)
+}
@@ -0,0 +1,48 @@
+package protoaxios_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/MOHAMMADmiZAN/go-axios/protoaxios"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// TestProtoBody verifies that protobuf messages are sent and decoded with their Content-Type.
+func TestProtoBody(t *testing.T) {
+	// Mock server that echoes the body back along with its Content-Type
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, ProtoCodec: protoaxios.Codec{}}, nil)
+	resp, err := client.Post(context.TODO(), server.URL, nil, axios.Config{ProtoBody: wrapperspb.String("hello")})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "application/x-protobuf", resp.ContentType(), "Content-Type should be set for protobuf bodies")
+
+	var decoded wrapperspb.StringValue
+	assert.NoError(t, resp.ParseProto(&decoded), "Protobuf parsing should not return an error")
+	assert.Equal(t, "hello", decoded.GetValue(), "Decoded message should round-trip")
+
+	decoded.Reset()
+	assert.NoError(t, resp.Decode(&decoded), "Decode should pick the protobuf codec from the Content-Type")
+	assert.Equal(t, "hello", decoded.GetValue(), "Decoded message should round-trip")
+
+	// Other content types and values that are not messages are rejected
+	resp.Headers.Set("Content-Type", "application/json")
+	assert.ErrorContains(t, resp.ParseProto(&decoded), "unexpected Content-Type", "Non-protobuf body should be rejected")
+	_, err = client.Post(context.TODO(), server.URL, nil, axios.Config{ProtoBody: "hello"})
+	assert.ErrorContains(t, err, "not a proto.Message", "Non-message bodies should be rejected")
+
+	// Malformed bodies fail to decode
+	invalid := &axios.Response{Body: []byte{0xff}, Config: axios.Config{ProtoCodec: protoaxios.Codec{}}}
+	assert.Error(t, invalid.ParseProto(&decoded), "Malformed protobuf should return an error")
+}
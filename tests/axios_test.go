@@ -2,11 +2,30 @@ package axios_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
+	"math/big"
 	"mime/multipart"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -70,6 +89,11 @@ func TestClientRequestError(t *testing.T) {
 	assert.Nil(t, resp, "Response should be nil on error")
 	assert.Error(t, err, "Request should return an error for 500 status")
 	assert.Contains(t, err.Error(), "500", "Error should contain status code")
+
+	reqErr, ok := axios.IsStatusError(err)
+	assert.True(t, ok, "Error should be classified as a status error")
+	assert.Equal(t, http.StatusInternalServerError, reqErr.StatusCode, "Status error should carry the status code")
+	assert.False(t, axios.IsTimeout(err), "Status error should not be classified as a timeout")
 }
 
 // TestInterceptorRequest ensures that the request interceptor modifies the request (e.g., setting headers).
@@ -148,6 +172,8 @@ func TestClientTimeout(t *testing.T) {
 	assert.Nil(t, resp, "Response should be nil on timeout")
 	assert.Error(t, err, "Request should return an error due to timeout")
 	assert.Contains(t, err.Error(), "context deadline exceeded", "Error should indicate a timeout")
+	assert.True(t, axios.IsTimeout(err), "Error should be classified as a timeout")
+	assert.False(t, axios.IsCanceled(err), "Timeout should not be classified as a cancellation")
 }
 
 // TestClientCustomHeaders verifies that custom headers are properly set in the request.
@@ -277,6 +303,7 @@ func TestClientCancelableRequest(t *testing.T) {
 	assert.Nil(t, resp, "Response should be nil on cancellation")
 	assert.Error(t, err, "Request should return an error due to cancellation")
 	assert.Contains(t, err.Error(), "context canceled", "Error should indicate cancellation")
+	assert.True(t, axios.IsCanceled(err), "Error should be classified as a cancellation")
 }
 
 // TestClientConcurrentRequests ensures that the client can handle multiple requests concurrently without issues like race conditions.
@@ -316,6 +343,65 @@ func TestClientConcurrentRequests(t *testing.T) {
 	}
 }
 
+// TestClientConcurrentConfigIsolation verifies that per-request headers and params neither leak between concurrent requests nor into the client defaults.
+func TestClientConcurrentConfigIsolation(t *testing.T) {
+	// Mock server that echoes the headers and query it received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s|%s|%s", r.Header.Get("X-Default"), r.Header.Values("X-Request"), r.URL.RawQuery)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Headers: http.Header{"X-Default": {"d"}},
+		Params:  map[string]string{"base": "1"},
+	}, nil)
+
+	const numRequests = 20
+	results := make(chan error, numRequests)
+	for i := 0; i < numRequests; i++ {
+		go func() {
+			id := fmt.Sprint(i)
+			resp, err := client.Get(context.TODO(), server.URL, axios.Config{
+				Headers: http.Header{"X-Request": {id}},
+				Params:  map[string]string{"req": id},
+			})
+			if err == nil && resp.String() != fmt.Sprintf("d|[%s]|base=1&req=%s", id, id) {
+				err = fmt.Errorf("request %s received %q", id, resp.String())
+			}
+			results <- err
+		}()
+	}
+	for i := 0; i < numRequests; i++ {
+		assert.NoError(t, <-results, "Each request should only carry its own headers and params")
+	}
+
+	// The defaults are left untouched
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "d|[]|base=1", resp.String(), "Per-request values should not leak into the defaults")
+}
+
+// TestClientMergedHeaderValues verifies that every value of a per-request header is sent and replaces the default's values.
+func TestClientMergedHeaderValues(t *testing.T) {
+	// Mock server that echoes all values of the X-Tag and X-Default headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s|%s", r.Header.Values("X-Tag"), r.Header.Values("X-Default"))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Headers: http.Header{"X-Tag": {"default"}, "X-Default": {"a", "b"}},
+	}, nil)
+
+	resp, err := client.Get(context.TODO(), server.URL, axios.Config{
+		Headers: http.Header{"x-tag": {"one", "two"}},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "[one two]|[a b]", resp.String(), "All values should be kept, with per-request headers replacing defaults")
+}
+
 // TestClientMultipleInterceptors   ensures that multiple interceptors are applied in the correct order and that they can modify both requests and responses.
 func TestClientMultipleInterceptors(t *testing.T) {
 	// Mock server setup
@@ -377,6 +463,40 @@ func TestClientLargePayload(t *testing.T) {
 	assert.Equal(t, len(largePayload), len(resp.Body), "Response body should match large payload size")
 }
 
+// TestClientMaxResponseBytes verifies that oversized response bodies are rejected, whether or not their length is declared.
+func TestClientMaxResponseBytes(t *testing.T) {
+	largePayload := bytes.Repeat([]byte("A"), 1<<20)
+
+	// Mock server that returns the large payload, declaring its length on /declared
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/declared" {
+			w.Header().Set("Content-Length", fmt.Sprint(len(largePayload)))
+		}
+		w.Write(largePayload)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// A body within the limit is read in full
+	resp, err := client.Get(context.TODO(), server.URL, axios.Config{MaxResponseBytes: 1 << 20})
+	assert.NoError(t, err, "Body at the limit should be accepted")
+	assert.Equal(t, len(largePayload), len(resp.Body), "Whole body should be read")
+
+	// Oversized bodies fail, both while reading and up front from Content-Length
+	_, err = client.Get(context.TODO(), server.URL, axios.Config{MaxResponseBytes: 1024})
+	assert.ErrorIs(t, err, axios.ErrResponseTooLarge, "Oversized chunked body should be rejected")
+	_, err = client.Get(context.TODO(), server.URL+"/declared", axios.Config{MaxResponseBytes: 1024})
+	assert.ErrorIs(t, err, axios.ErrResponseTooLarge, "Oversized declared body should be rejected")
+
+	// Streamed bodies are limited as they are read
+	stream, err := client.RequestStream(context.TODO(), axios.Config{Method: "GET", URL: server.URL, MaxResponseBytes: 1024})
+	assert.NoError(t, err, "Stream should open")
+	defer stream.RawBody.Close()
+	_, err = io.ReadAll(stream.RawBody)
+	assert.ErrorIs(t, err, axios.ErrResponseTooLarge, "Oversized stream should be rejected while reading")
+}
+
 // TestClientRetryLogic  implements retry logic in case of failure (e.g., 500 response) and ensures the client can recover after a certain number of retries.
 func TestClientRetryLogic(t *testing.T) {
 	// Track the number of requests to simulate retry behavior
@@ -480,4 +600,2085 @@ func TestClientRedirectHandling(t *testing.T) {
 	// Ensure request succeeds and follows the redirect
 	assert.NoError(t, err, "Request should succeed")
 	assert.Contains(t, string(resp.Body), "final destination", "Response should follow the redirect")
+	assert.Equal(t, server.URL+"/final", resp.FinalURL, "FinalURL should be the redirect target")
+
+	// A streamed response reports its final URL too
+	stream, err := client.RequestStream(context.TODO(), axios.Config{URL: server.URL + "/redirect"})
+	assert.NoError(t, err, "Stream should open")
+	stream.RawBody.Close()
+	assert.Equal(t, server.URL+"/final", stream.FinalURL, "Streamed FinalURL should be the redirect target")
+}
+
+// TestClientConfigParams verifies that Config.Params are encoded and merged with the URL's existing query string.
+func TestClientConfigParams(t *testing.T) {
+	// Mock server setup to verify query parameters
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("existing"), "Existing query param should be preserved")
+		assert.Equal(t, "2", r.URL.Query().Get("page"), "Config param should be applied")
+		assert.Equal(t, "a b&c", r.URL.Query().Get("q"), "Config param should be percent-encoded")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// Execute the GET request with params
+	resp, err := client.Request(context.TODO(), axios.Config{
+		Method: "GET",
+		URL:    server.URL + "?existing=1",
+		Params: map[string]string{"page": "2", "q": "a b&c"},
+	})
+
+	// Assert the request was successful
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be 200 OK")
+}
+
+// TestClientAppliesResponseInterceptors verifies that response interceptors registered on the client run automatically.
+func TestClientAppliesResponseInterceptors(t *testing.T) {
+	// Mock server setup to return a response body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message": "original"}`))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// Add a response-only interceptor to modify the response body
+	client.GetInterceptorManager().AddInterceptor(axios.Interceptor{
+		Response: func(resp *axios.Response) (*axios.Response, error) {
+			resp.Body = []byte(`{"message": "intercepted"}`)
+			return resp, nil
+		},
+	})
+
+	// Execute the request; the interceptor should be applied by Request itself
+	resp, err := client.Request(context.TODO(), axios.Config{Method: "GET", URL: server.URL})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Contains(t, string(resp.Body), "intercepted", "Response should be intercepted and modified")
+}
+
+// TestClientConvenienceMethods verifies that the helper methods send the expected HTTP method, body and options.
+func TestClientConvenienceMethods(t *testing.T) {
+	// Mock server setup that echoes the method and body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "yes", r.Header.Get("X-Test"), "Headers from opts should be sent")
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	opts := axios.Config{Headers: http.Header{"X-Test": []string{"yes"}}}
+	ctx := context.TODO()
+
+	// Methods without a body
+	for method, call := range map[string]func() (*axios.Response, error){
+		http.MethodGet:     func() (*axios.Response, error) { return client.Get(ctx, server.URL, opts) },
+		http.MethodDelete:  func() (*axios.Response, error) { return client.Delete(ctx, server.URL, opts) },
+		http.MethodHead:    func() (*axios.Response, error) { return client.Head(ctx, server.URL, opts) },
+		http.MethodOptions: func() (*axios.Response, error) { return client.Options(ctx, server.URL, opts) },
+	} {
+		resp, err := call()
+		assert.NoError(t, err, "%s request should succeed", method)
+		assert.Equal(t, method, resp.Headers.Get("X-Method"), "Server should receive %s", method)
+	}
+
+	// Methods with a body
+	for method, call := range map[string]func() (*axios.Response, error){
+		http.MethodPost:  func() (*axios.Response, error) { return client.Post(ctx, server.URL, []byte("payload"), opts) },
+		http.MethodPut:   func() (*axios.Response, error) { return client.Put(ctx, server.URL, []byte("payload"), opts) },
+		http.MethodPatch: func() (*axios.Response, error) { return client.Patch(ctx, server.URL, []byte("payload"), opts) },
+	} {
+		resp, err := call()
+		assert.NoError(t, err, "%s request should succeed", method)
+		assert.Equal(t, method, resp.Headers.Get("X-Method"), "Server should receive %s", method)
+		assert.Equal(t, "payload", string(resp.Body), "Server should receive the %s body", method)
+	}
+}
+
+// TestClientBaseURL verifies that relative URLs resolve against the client's BaseURL while absolute URLs are used as-is.
+func TestClientBaseURL(t *testing.T) {
+	// Mock server setup that echoes the request path
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, BaseURL: server.URL + "/v1"}, nil)
+
+	// Relative URL keeps the base path
+	resp, err := client.Request(context.TODO(), axios.Config{Method: "GET", URL: "/posts/1"})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "/v1/posts/1", string(resp.Body), "Relative URL should resolve against BaseURL")
+
+	// Absolute URL ignores the base
+	resp, err = client.Request(context.TODO(), axios.Config{Method: "GET", URL: server.URL + "/other"})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "/other", string(resp.Body), "Absolute URL should be used as-is")
+}
+
+// TestClientPathParams verifies that URL placeholders are filled in and escaped, and that missing values are reported.
+func TestClientPathParams(t *testing.T) {
+	// Mock server setup that echoes the escaped request path
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.EscapedPath()))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		Timeout:    10,
+		BaseURL:    server.URL + "/v1",
+		PathParams: map[string]string{"id": "42"},
+	}, nil)
+
+	// Client-wide params are combined with per-request ones and each value is escaped
+	resp, err := client.Get(context.TODO(), "/users/{id}/posts/{postId}", axios.Config{
+		PathParams: map[string]string{"postId": "a/b c"},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "/v1/users/42/posts/a%2Fb%20c", string(resp.Body), "Placeholders should be replaced with escaped values")
+
+	// A placeholder without a value is an error
+	_, err = client.Get(context.TODO(), "/users/{id}/posts/{postId}")
+	assert.ErrorContains(t, err, `missing path parameter "postId"`, "Missing path params should be reported")
+
+	// Without any path params the placeholder is still reported instead of being sent literally
+	var hits atomic.Int32
+	bare := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer bare.Close()
+	_, err = axios.NewClient(axios.Config{Timeout: 10}, nil).Get(context.TODO(), bare.URL+"/users/{id}")
+	assert.ErrorContains(t, err, `missing path parameter "id"`, "Placeholders without path params should be reported")
+	assert.Equal(t, int32(0), hits.Load(), "Request with an unresolved placeholder should not be sent")
+}
+
+// TestClientBuiltInRetry verifies that the client retries 5xx responses and resends the request body.
+func TestClientBuiltInRetry(t *testing.T) {
+	// Mock server that fails twice, then succeeds
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body), "Body should be resent on every attempt")
+		if requestCount < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"message": "success"}`))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 3, BaseDelay: 10 * time.Millisecond},
+	}, nil)
+
+	resp, err := client.Put(context.TODO(), server.URL, []byte("payload"))
+	assert.NoError(t, err, "Request should succeed after retries")
+	assert.Equal(t, 3, requestCount, "Server should be hit three times")
+	assert.Contains(t, string(resp.Body), "success", "Response should contain success message")
+
+	// Client errors are not retried by default
+	requestCount = 0
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer notFound.Close()
+
+	_, err = client.Get(context.TODO(), notFound.URL)
+	assert.Error(t, err, "404 should be returned as an error")
+	assert.Equal(t, 1, requestCount, "4xx responses should not be retried")
+}
+
+// TestClientRetryMethodsAndStatusCodes verifies that only idempotent methods and the listed statuses are retried.
+func TestClientRetryMethodsAndStatusCodes(t *testing.T) {
+	// Mock server that always fails with the status given in the path
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		status, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/"))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		BaseURL: server.URL,
+		Retry:   &axios.RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond},
+	}, nil)
+
+	// POST is not retried by default, to avoid double submits
+	client.Post(context.TODO(), "/503", []byte("order"))
+	assert.Equal(t, 1, requestCount, "POST should not be retried by default")
+
+	// Unless it carries an idempotency key
+	requestCount = 0
+	client.Post(context.TODO(), "/503", []byte("order"), axios.Config{IdempotencyKey: "order-1"})
+	assert.Equal(t, 3, requestCount, "POST with an idempotency key should be retried")
+
+	// Or POST is allowed explicitly
+	requestCount = 0
+	client.Post(context.TODO(), "/503", []byte("order"), axios.Config{
+		Retry: &axios.RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, RetryMethods: []string{"POST"}},
+	})
+	assert.Equal(t, 3, requestCount, "POST listed in RetryMethods should be retried")
+
+	// Only the listed statuses are retried
+	gateway := &axios.RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, RetryStatusCodes: []int{502, 504}}
+	requestCount = 0
+	client.Get(context.TODO(), "/503", axios.Config{Retry: gateway})
+	assert.Equal(t, 1, requestCount, "Unlisted status should not be retried")
+
+	requestCount = 0
+	client.Get(context.TODO(), "/502", axios.Config{Retry: gateway})
+	assert.Equal(t, 3, requestCount, "Listed status should be retried")
+}
+
+// TestClientIdempotencyKey verifies that idempotency keys are sent and reused across retries.
+func TestClientIdempotencyKey(t *testing.T) {
+	// Mock server that fails the first attempt of every call and records the keys it sees
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond},
+	}, nil)
+
+	// An explicit key is sent on every attempt
+	_, err := client.Post(context.TODO(), server.URL, []byte("order"), axios.Config{IdempotencyKey: "order-42"})
+	assert.NoError(t, err, "Request should succeed after a retry")
+	assert.Equal(t, []string{"order-42", "order-42"}, keys, "Explicit key should be reused across retries")
+
+	// Generated keys are stable across retries but differ between calls
+	keys = nil
+	for i := 0; i < 2; i++ {
+		_, err = client.Post(context.TODO(), server.URL, []byte("order"), axios.Config{AutoIdempotencyKey: true})
+		assert.NoError(t, err, "Request should succeed after a retry")
+	}
+	if assert.Len(t, keys, 4, "Each call should be retried once") {
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, keys[0], "Generated key should be a UUID")
+		assert.Equal(t, keys[0], keys[1], "Generated key should be reused across retries")
+		assert.NotEqual(t, keys[0], keys[2], "Each call should get a new key")
+	}
+
+	// Safe methods do not get generated keys
+	keys = nil
+	client.Get(context.TODO(), server.URL, axios.Config{AutoIdempotencyKey: true})
+	assert.Equal(t, "", keys[0], "GET should not get an idempotency key")
+}
+
+// TestClientBodyReader verifies streamed request bodies, and that only seekable readers are retried.
+func TestClientBodyReader(t *testing.T) {
+	// Mock server that fails the first attempt and echoes the body with its framing
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		body, _ := io.ReadAll(r.Body)
+		if requestCount == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, "%d:%s", r.ContentLength, body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 2, BaseDelay: 10 * time.Millisecond},
+	}, nil)
+
+	// A seekable reader is rewound and resent in full on retry
+	resp, err := client.Put(context.TODO(), server.URL, nil, axios.Config{BodyReader: strings.NewReader("payload")})
+	assert.NoError(t, err, "Request should succeed after a retry")
+	assert.Equal(t, "7:payload", string(resp.Body), "Retry should resend the whole body with its length")
+	assert.Equal(t, 2, requestCount, "Server should be hit twice")
+
+	// A non-seekable reader is streamed chunked with an unknown length and not retried
+	requestCount = 0
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("streamed"))
+		pw.Close()
+	}()
+	_, err = client.Put(context.TODO(), server.URL, nil, axios.Config{BodyReader: pr})
+	assert.Error(t, err, "Failure should be returned rather than retried")
+	assert.Equal(t, 1, requestCount, "Non-seekable body should not be retried")
+
+	// An explicit ContentLength is sent for readers that cannot report their size
+	pr, pw = io.Pipe()
+	go func() {
+		pw.Write([]byte("sized"))
+		pw.Close()
+	}()
+	resp, err = client.Post(context.TODO(), server.URL, nil, axios.Config{BodyReader: pr, ContentLength: 5})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "5:sized", string(resp.Body), "Content-Length should be set from the config")
+}
+
+// TestClientRetryAfter verifies that a Retry-After header overrides the backoff and is capped by MaxRetryAfter.
+func TestClientRetryAfter(t *testing.T) {
+	// Mock server that rate limits the first request
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if requestCount == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The backoff alone would wait 2s; the capped Retry-After should win
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry: &axios.RetryConfig{
+			MaxRetries:    1,
+			BaseDelay:     2 * time.Second,
+			MaxRetryAfter: 50 * time.Millisecond,
+		},
+	}, nil)
+
+	start := time.Now()
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed after the rate limit")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be 200 OK")
+	assert.Less(t, time.Since(start), time.Second, "Retry-After capped by MaxRetryAfter should be used instead of the backoff")
+}
+
+// TestClientRetryCancelDuringBackoff verifies that cancelling the context interrupts the wait between retries.
+func TestClientRetryCancelDuringBackoff(t *testing.T) {
+	// Mock server that always fails and reports each attempt
+	attempts := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts <- struct{}{}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 3, BaseDelay: 10 * time.Second},
+	}, nil)
+
+	// Cancel once the first attempt has failed and the client is backing off
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-attempts
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := client.Get(ctx, server.URL)
+	assert.ErrorIs(t, err, context.Canceled, "Cancelling during the backoff should return the context error")
+	assert.ErrorContains(t, err, "waiting to retry", "Error should say the client was waiting to retry")
+	assert.Less(t, time.Since(start), time.Second, "Request should return promptly instead of finishing the backoff")
+	assert.Empty(t, attempts, "No further attempt should be sent after cancellation")
+}
+
+// TestClientRetryMaxDelay verifies that the backoff is capped by MaxDelay, even after many attempts.
+func TestClientRetryMaxDelay(t *testing.T) {
+	// Mock server that always fails
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// An hour-long base delay waits only the cap
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 1, BaseDelay: time.Hour, MaxDelay: 50 * time.Millisecond},
+	}, nil)
+	start := time.Now()
+	_, err := client.Get(context.TODO(), server.URL)
+	assert.Error(t, err, "Request should fail once the retries are used up")
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond, "Backoff should wait the capped delay")
+	assert.Less(t, elapsed, time.Second, "Backoff should not exceed the cap")
+
+	// Doubling far past the range of a time.Duration must neither overflow nor exceed the cap
+	attempts.Store(0)
+	client = axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 100, BaseDelay: time.Hour, MaxDelay: time.Millisecond},
+	}, nil)
+	start = time.Now()
+	_, err = client.Get(context.TODO(), server.URL)
+	assert.Error(t, err, "Request should fail once the retries are used up")
+	assert.Equal(t, int32(101), attempts.Load(), "Every retry should be attempted")
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "Every retry should wait the capped delay")
+	assert.Less(t, time.Since(start), 10*time.Second, "Retries should not wait longer than the cap")
+}
+
+// TestDefaultRetryOn verifies that only network errors, timeouts and retryable statuses are retried by default.
+func TestDefaultRetryOn(t *testing.T) {
+	network := &url.Error{Op: "Get", URL: "http://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	assert.True(t, axios.DefaultRetryOn(nil, fmt.Errorf("executing request: %w", network)), "Connection failures should be retried")
+	assert.True(t, axios.DefaultRetryOn(nil, &url.Error{Op: "Get", URL: "http://example.com", Err: io.EOF}), "Connections closed before a response should be retried")
+	assert.True(t, axios.DefaultRetryOn(nil, axios.ErrBodyReadTimeout), "Body read timeouts should be retried")
+	assert.True(t, axios.DefaultRetryOn(nil, &axios.RequestError{StatusCode: http.StatusServiceUnavailable}), "5xx statuses should be retried")
+	assert.True(t, axios.DefaultRetryOn(nil, &axios.RequestError{StatusCode: http.StatusTooManyRequests}), "429 should be retried")
+
+	assert.False(t, axios.DefaultRetryOn(nil, &axios.RequestError{StatusCode: http.StatusNotFound}), "4xx statuses should not be retried")
+	assert.False(t, axios.DefaultRetryOn(nil, fmt.Errorf("applying request interceptors: %w", errors.New("no token"))), "Interceptor errors should not be retried")
+	assert.False(t, axios.DefaultRetryOn(nil, fmt.Errorf("%w: missing URL", axios.ErrInvalidConfig)), "Invalid configs should not be retried")
+	assert.False(t, axios.DefaultRetryOn(nil, axios.ErrResponseTooLarge), "Oversized responses should not be retried")
+	assert.False(t, axios.DefaultRetryOn(nil, &url.Error{Op: "Get", URL: "http://example.com", Err: axios.ErrTooManyRedirects}), "Redirect policy errors should not be retried")
+	assert.False(t, axios.DefaultRetryOn(nil, axios.ErrCircuitOpen), "Open circuits should not be retried")
+	assert.False(t, axios.DefaultRetryOn(nil, &url.Error{Op: "Get", URL: "http://example.com", Err: context.Canceled}), "Cancellation should not be retried")
+
+	// A failing request interceptor is reported after a single attempt
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond},
+	}, nil)
+	calls := 0
+	client.GetInterceptorManager().AddRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		calls++
+		return nil, errors.New("no token")
+	})
+	_, err := client.Get(context.TODO(), "http://example.com")
+	assert.ErrorContains(t, err, "no token", "Interceptor error should be returned")
+	assert.Equal(t, 1, calls, "Interceptor error should not be retried")
+}
+
+// TestClientRetryJitter verifies that full and equal jitter randomize the backoff using the injected source.
+func TestClientRetryJitter(t *testing.T) {
+	// Mock server that fails the first attempt of every request
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	draws := 0
+	zero := func() float64 { draws++; return 0 }
+
+	// Full jitter drawing zero skips an hour-long backoff entirely
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 1, BaseDelay: time.Hour, Jitter: axios.FullJitter, Rand: zero},
+	}, nil)
+	start := time.Now()
+	_, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed after a retry")
+	assert.Less(t, time.Since(start), time.Second, "Full jitter should scale the backoff by the random draw")
+	assert.Equal(t, 1, draws, "Jitter should draw from the injected source")
+
+	// Equal jitter keeps at least half of the backoff
+	client = axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 1, BaseDelay: 200 * time.Millisecond, Jitter: axios.EqualJitter, Rand: zero},
+	}, nil)
+	start = time.Now()
+	_, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed after a retry")
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond, "Equal jitter should wait at least half the backoff")
+	assert.Less(t, elapsed, 200*time.Millisecond, "Equal jitter drawing zero should wait only half the backoff")
+}
+
+// TestClientRequestTimeoutDuration ensures that sub-second timeouts can be configured with RequestTimeout.
+func TestClientRequestTimeoutDuration(t *testing.T) {
+	// Mock server setup with a delayed response to trigger timeout
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// RequestTimeout takes precedence over the deprecated Timeout in seconds
+	client := axios.NewClient(axios.Config{Timeout: 10, RequestTimeout: 100 * time.Millisecond}, nil)
+	assert.Equal(t, 100*time.Millisecond, client.HTTPClient().Timeout, "Timeout should use RequestTimeout")
+
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.Nil(t, resp, "Response should be nil on timeout")
+	assert.Error(t, err, "Request should return an error due to timeout")
+}
+
+// TestClientPerRequestTimeout verifies that a per-request timeout overrides the client default in both directions.
+func TestClientPerRequestTimeout(t *testing.T) {
+	// Mock server setup with a delayed response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// A larger per-request timeout lets the slow request finish despite the short client timeout
+	client := axios.NewClient(axios.Config{RequestTimeout: 100 * time.Millisecond}, nil)
+	resp, err := client.Get(context.TODO(), server.URL, axios.Config{RequestTimeout: 2 * time.Second})
+	assert.NoError(t, err, "Per-request timeout should take precedence over the client timeout")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be 200 OK")
+
+	// A smaller per-request timeout cuts the request short despite the long client timeout
+	client = axios.NewClient(axios.Config{Timeout: 10}, nil)
+	_, err = client.Get(context.TODO(), server.URL, axios.Config{RequestTimeout: 50 * time.Millisecond})
+	assert.Error(t, err, "Request should time out")
+	assert.Contains(t, err.Error(), "context deadline exceeded", "Error should indicate a timeout")
+}
+
+// TestClientJSONBody verifies that JSONBody is marshaled, sent with a JSON Content-Type, and conflicts with Body.
+func TestClientJSONBody(t *testing.T) {
+	// Mock server setup that echoes the body and Content-Type
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Content-Type", r.Header.Get("Content-Type"))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// JSONBody is marshaled and the Content-Type defaults to JSON
+	resp, err := client.Request(context.TODO(), axios.Config{
+		Method:   "POST",
+		URL:      server.URL,
+		JSONBody: map[string]string{"title": "foo"},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.JSONEq(t, `{"title": "foo"}`, string(resp.Body), "Body should be the marshaled JSON")
+	assert.Equal(t, "application/json", resp.Headers.Get("X-Content-Type"), "Content-Type should default to JSON")
+
+	// An explicit Content-Type is kept
+	resp, err = client.Request(context.TODO(), axios.Config{
+		Method:   "POST",
+		URL:      server.URL,
+		JSONBody: map[string]string{"title": "foo"},
+		Headers:  http.Header{"Content-Type": []string{"application/vnd.api+json"}},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "application/vnd.api+json", resp.Headers.Get("X-Content-Type"), "Explicit Content-Type should win")
+
+	// Body and JSONBody together are rejected
+	_, err = client.Request(context.TODO(), axios.Config{
+		Method:   "POST",
+		URL:      server.URL,
+		Body:     []byte("raw"),
+		JSONBody: map[string]string{"title": "foo"},
+	})
+	assert.ErrorIs(t, err, axios.ErrConflictingBody, "Setting both bodies should fail")
+}
+
+// TestClientResult verifies that a JSON response is decoded into Config.Result for a typed round-trip.
+func TestClientResult(t *testing.T) {
+	type post struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	}
+
+	// Mock server setup that echoes the posted JSON with an ID
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/invalid" {
+			w.Write([]byte(`not json`))
+			return
+		}
+		var received map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&received)
+		received["id"] = 7
+		json.NewEncoder(w).Encode(received)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// Post a struct and decode the response into another
+	var created post
+	resp, err := client.Request(context.TODO(), axios.Config{
+		Method:   "POST",
+		URL:      server.URL,
+		JSONBody: post{Title: "foo"},
+		Result:   &created,
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be 200 OK")
+	assert.Equal(t, post{ID: 7, Title: "foo"}, created, "Result should be decoded from the response")
+
+	// Invalid JSON surfaces a decode error
+	_, err = client.Request(context.TODO(), axios.Config{
+		Method: "GET",
+		URL:    server.URL + "/invalid",
+		Result: &created,
+	})
+	var decodeErr *axios.DecodeError
+	assert.ErrorAs(t, err, &decodeErr, "Invalid JSON should return a decode error")
+}
+
+// TestRequestJSON verifies the generic helper decodes into T and distinguishes decode errors.
+func TestRequestJSON(t *testing.T) {
+	type message struct {
+		Message string `json:"message"`
+	}
+
+	// Mock server setup returning JSON, or invalid JSON for /invalid
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/invalid" {
+			w.Write([]byte(`not json`))
+			return
+		}
+		w.Write([]byte(`{"message": "success"}`))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	msg, resp, err := axios.RequestJSON[message](context.TODO(), client, axios.Config{Method: "GET", URL: server.URL})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Raw response should be returned")
+	assert.Equal(t, "success", msg.Message, "Body should be decoded into T")
+
+	_, resp, err = axios.RequestJSON[message](context.TODO(), client, axios.Config{Method: "GET", URL: server.URL + "/invalid"})
+	var decodeErr *axios.DecodeError
+	assert.ErrorAs(t, err, &decodeErr, "Invalid JSON should return a decode error")
+	assert.NotNil(t, resp, "Raw response should be returned alongside a decode error")
+}
+
+// TestClientFormBody verifies that Config.Form is sent URL-encoded with multiple values per key preserved.
+func TestClientFormBody(t *testing.T) {
+	// Mock server setup to verify the parsed form
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"), "Content-Type should default to form encoding")
+		assert.NoError(t, r.ParseForm(), "Parsing form should succeed")
+		assert.Equal(t, []string{"a", "b"}, r.PostForm["tag"], "Multiple values per key should be preserved")
+		assert.Equal(t, "x y&z", r.PostForm.Get("name"), "Values should be encoded")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	resp, err := client.Request(context.TODO(), axios.Config{
+		Method: "POST",
+		URL:    server.URL,
+		Form:   url.Values{"tag": {"a", "b"}, "name": {"x y&z"}},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be 200 OK")
+}
+
+// TestClientCompressRequest verifies that large bodies are gzipped with a matching Content-Length while small ones are sent as-is.
+func TestClientCompressRequest(t *testing.T) {
+	// Mock server that decompresses gzipped bodies and reports what it received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		assert.Equal(t, int64(len(raw)), r.ContentLength, "Content-Length should match the bytes sent")
+
+		body := raw
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			reader, err := gzip.NewReader(bytes.NewReader(raw))
+			assert.NoError(t, err, "Body should be valid gzip")
+			body, _ = io.ReadAll(reader)
+		}
+		fmt.Fprintf(w, "%s %d %d %s", r.Header.Get("Content-Encoding"), len(raw), len(body), r.Header.Get("Content-Type"))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, CompressRequest: true}, nil)
+
+	large := map[string]string{"data": strings.Repeat("a", 4096)}
+	resp, err := client.Post(context.TODO(), server.URL, nil, axios.Config{JSONBody: large})
+	assert.NoError(t, err, "Request should succeed")
+	var encoding, contentType string
+	var sent, decoded int
+	fmt.Sscanf(resp.String(), "%s %d %d %s", &encoding, &sent, &decoded, &contentType)
+	assert.Equal(t, "gzip", encoding, "Large body should be gzipped")
+	assert.Less(t, sent, decoded, "Compressed body should be smaller")
+	assert.Equal(t, 4096+len(`{"data":""}`), decoded, "Decompressed body should be complete")
+	assert.Equal(t, "application/json", contentType, "Content-Type should be kept")
+
+	resp, err = client.Post(context.TODO(), server.URL, []byte("small"))
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, " 5 5 ", resp.String(), "Small body should be sent uncompressed")
+}
+
+// TestClientMultipartFiles verifies that Fields and Files are sent as a streamed multipart/form-data body.
+func TestClientMultipartFiles(t *testing.T) {
+	// Mock server setup to check the uploaded parts
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseMultipartForm(10 << 20) // 10MB max memory
+		assert.NoError(t, err, "Parsing multipart form should succeed")
+		assert.Equal(t, "hello", r.FormValue("greeting"), "Field should be sent")
+
+		file, header, err := r.FormFile("file")
+		assert.NoError(t, err, "Retrieving file should succeed")
+		contents, _ := io.ReadAll(file)
+		assert.Equal(t, "test.txt", header.Filename, "File name should match")
+		assert.Equal(t, "test", string(contents), "File content should match")
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	resp, err := client.Request(context.TODO(), axios.Config{
+		Method: "POST",
+		URL:    server.URL,
+		Fields: map[string]string{"greeting": "hello"},
+		Files: []axios.FormFile{
+			{FieldName: "file", FileName: "test.txt", Reader: strings.NewReader("test")},
+		},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be 200 OK")
+}
+
+// TestClientRequestStream verifies that streaming mode returns an open body instead of buffering it.
+func TestClientRequestStream(t *testing.T) {
+	// Create a large payload (1MB of 'A's)
+	largePayload := bytes.Repeat([]byte("A"), 1<<20)
+
+	// Mock server that returns a large response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(largePayload)
+	}))
+	defer server.Close()
+
+	// A per-request timeout must not cut the stream off once Request returns
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	resp, err := client.RequestStream(context.TODO(), axios.Config{
+		Method:         "GET",
+		URL:            server.URL,
+		RequestTimeout: 5 * time.Second,
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Nil(t, resp.Body, "Body should not be buffered in streaming mode")
+
+	// Read the stream incrementally
+	n, err := io.Copy(io.Discard, resp.RawBody)
+	assert.NoError(t, err, "Reading the stream should succeed")
+	assert.Equal(t, int64(len(largePayload)), n, "Stream should contain the full payload")
+	assert.NoError(t, resp.RawBody.Close(), "Closing the stream should succeed")
+}
+
+// TestClientRequestStreamCancel verifies that a stream is closed once its context is done and can be closed repeatedly.
+func TestClientRequestStreamCancel(t *testing.T) {
+	// Mock server that sends the headers, then never finishes the body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("start"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	// A single concurrency slot is held by the open stream
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetMaxConcurrency(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resp, err := client.RequestStream(ctx, axios.Config{URL: server.URL})
+	assert.NoError(t, err, "Stream should open")
+
+	// Cancelling without closing the body must still free the slot for the next request
+	cancel()
+	next, nextCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer nextCancel()
+	second, err := client.RequestStream(next, axios.Config{URL: server.URL})
+	if assert.NoError(t, err, "Next request should get the slot released by the cancelled stream") {
+		second.RawBody.Close()
+	}
+
+	_, err = io.ReadAll(resp.RawBody)
+	assert.Error(t, err, "Reading a cancelled stream should fail")
+	assert.NoError(t, resp.RawBody.Close(), "Closing a stream closed by cancellation should be a no-op")
+	assert.NoError(t, resp.RawBody.Close(), "Closing a stream twice should be a no-op")
+}
+
+// TestClientRequestStreamInterceptorError verifies that a stream rejected by a response interceptor is closed.
+func TestClientRequestStreamInterceptorError(t *testing.T) {
+	// Mock server that sends the headers, then never finishes the body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("start"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	// A single concurrency slot is held by the open stream
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetMaxConcurrency(1)
+	id := client.GetInterceptorManager().AddResponseInterceptor(func(resp *axios.Response) (*axios.Response, error) {
+		return nil, errors.New("rejected")
+	})
+
+	_, err := client.RequestStream(context.Background(), axios.Config{URL: server.URL})
+	assert.Error(t, err, "Interceptor error should be returned")
+
+	// The rejected stream must not keep the slot
+	client.GetInterceptorManager().RemoveInterceptor(id)
+	next, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := client.RequestStream(next, axios.Config{URL: server.URL})
+	if assert.NoError(t, err, "Next request should get the slot released by the rejected stream") {
+		resp.RawBody.Close()
+	}
+}
+
+// TestClientDownload verifies that Download writes the body to disk and removes partial files on failure.
+func TestClientDownload(t *testing.T) {
+	// Mock server that serves a file, or stalls mid-body on /slow
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("file contents"))
+		if r.URL.Path == "/slow" {
+			w.(http.Flusher).Flush()
+			time.Sleep(2 * time.Second)
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	dest := filepath.Join(t.TempDir(), "download.txt")
+
+	// Successful download
+	err := client.Download(context.TODO(), axios.Config{Method: "GET", URL: server.URL}, dest)
+	assert.NoError(t, err, "Download should succeed")
+	contents, err := os.ReadFile(dest)
+	assert.NoError(t, err, "Downloaded file should exist")
+	assert.Equal(t, "file contents", string(contents), "File should contain the response body")
+
+	// Canceled download leaves no partial file
+	partial := filepath.Join(t.TempDir(), "partial.txt")
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err = client.Download(ctx, axios.Config{Method: "GET", URL: server.URL + "/slow"}, partial)
+	assert.Error(t, err, "Download should fail when the context is canceled")
+	_, statErr := os.Stat(partial)
+	assert.True(t, os.IsNotExist(statErr), "Partial file should be removed")
+
+	// A failed download leaves an existing file and its directory as they were
+	ctx, cancel = context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err = client.Download(ctx, axios.Config{Method: "GET", URL: server.URL + "/slow"}, dest)
+	assert.Error(t, err, "Download should fail when the context is canceled")
+	contents, err = os.ReadFile(dest)
+	assert.NoError(t, err, "Existing file should survive a failed download")
+	assert.Equal(t, "file contents", string(contents), "Existing file should keep its contents")
+	entries, err := os.ReadDir(filepath.Dir(dest))
+	assert.NoError(t, err, "Download directory should be readable")
+	assert.Len(t, entries, 1, "No temporary file should be left behind")
+}
+
+// TestClientUploadProgress verifies that upload progress is reported with the known total and -1 for streamed bodies.
+func TestClientUploadProgress(t *testing.T) {
+	// Mock server that drains the request body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	payload := bytes.Repeat([]byte("A"), 1<<20)
+
+	// Known length body reports the total
+	var written, total int64
+	_, err := client.Post(context.TODO(), server.URL, payload, axios.Config{
+		OnUploadProgress: func(bytesWritten, totalBytes int64) {
+			written, total = bytesWritten, totalBytes
+		},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, int64(len(payload)), written, "All bytes should be reported")
+	assert.Equal(t, int64(len(payload)), total, "Total should match the body length")
+
+	// Streamed multipart body reports an unknown total
+	_, err = client.Request(context.TODO(), axios.Config{
+		Method: "POST",
+		URL:    server.URL,
+		Files:  []axios.FormFile{{FieldName: "file", FileName: "a.bin", Reader: bytes.NewReader(payload)}},
+		OnUploadProgress: func(bytesWritten, totalBytes int64) {
+			written, total = bytesWritten, totalBytes
+		},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Greater(t, written, int64(len(payload)), "Multipart overhead should be included")
+	assert.Equal(t, int64(-1), total, "Total should be -1 when unknown")
+}
+
+// TestClientDownloadProgress verifies download progress in buffered and streaming modes.
+func TestClientDownloadProgress(t *testing.T) {
+	payload := bytes.Repeat([]byte("A"), 1<<20)
+
+	// Mock server that omits Content-Length on /chunked
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chunked" {
+			w.Header().Set("Content-Length", fmt.Sprint(len(payload)))
+		}
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	var read, total int64
+	progress := axios.Config{
+		OnDownloadProgress: func(bytesRead, totalBytes int64) {
+			read, total = bytesRead, totalBytes
+		},
+	}
+
+	// Buffered response with Content-Length
+	_, err := client.Get(context.TODO(), server.URL, progress)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, int64(len(payload)), read, "All bytes should be reported")
+	assert.Equal(t, int64(len(payload)), total, "Total should come from Content-Length")
+
+	// Streamed response without Content-Length
+	progress.Stream = true
+	resp, err := client.Get(context.TODO(), server.URL+"/chunked", progress)
+	assert.NoError(t, err, "Request should succeed")
+	io.Copy(io.Discard, resp.RawBody)
+	resp.RawBody.Close()
+	assert.Equal(t, int64(len(payload)), read, "All streamed bytes should be reported")
+	assert.Equal(t, int64(-1), total, "Total should be -1 without Content-Length")
+}
+
+// TestClientBasicAuth verifies that BasicAuth sets credentials without overriding an explicit Authorization header.
+func TestClientBasicAuth(t *testing.T) {
+	// Mock server that echoes the Authorization header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, BasicAuth: &axios.BasicAuth{Username: "user", Password: "pass"}}, nil)
+
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "Basic dXNlcjpwYXNz", string(resp.Body), "Basic credentials should be sent")
+
+	resp, err = client.Get(context.TODO(), server.URL, axios.Config{Headers: http.Header{"Authorization": []string{"Bearer explicit"}}})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "Bearer explicit", string(resp.Body), "Explicit Authorization header should win")
+}
+
+// TestClientBearerAuth verifies the BearerToken field and the reusable BearerAuthInterceptor.
+func TestClientBearerAuth(t *testing.T) {
+	// Mock server that echoes every Authorization header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Join(r.Header.Values("Authorization"), ", ")))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	resp, err := client.Get(context.TODO(), server.URL, axios.Config{BearerToken: "config-token"})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "Bearer config-token", string(resp.Body), "BearerToken should be sent")
+
+	client.GetInterceptorManager().AddInterceptor(axios.BearerAuthInterceptor("interceptor-token"))
+	resp, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "Bearer interceptor-token", string(resp.Body), "Interceptor should set the bearer token")
+
+	// The interceptor replaces an Authorization header from the config rather than adding a second one
+	resp, err = client.Get(context.TODO(), server.URL, axios.Config{
+		Headers: http.Header{"Authorization": []string{"Bearer header-token"}},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "Bearer interceptor-token", string(resp.Body), "Interceptor's token should be the only one sent")
+}
+
+// TestOAuth2Interceptor verifies that a 401 triggers one token refresh and a replay of the request.
+func TestOAuth2Interceptor(t *testing.T) {
+	// Mock server that only accepts the refreshed token
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body), "Body should be sent on every attempt")
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"message": "success"}`))
+	}))
+	defer server.Close()
+
+	token, refreshes := "stale", 0
+	tokenSource := func(ctx context.Context) (string, error) { return token, nil }
+
+	// Refreshing fixes the token and the request is replayed
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.GetInterceptorManager().AddInterceptor(axios.NewOAuth2Interceptor(tokenSource, func(ctx context.Context) error {
+		refreshes++
+		token = "fresh"
+		return nil
+	}))
+
+	resp, err := client.Post(context.TODO(), server.URL, []byte("payload"))
+	assert.NoError(t, err, "Request should succeed after refreshing the token")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be 200 OK")
+	assert.Equal(t, 1, refreshes, "Token should be refreshed once")
+
+	// A refresh that doesn't help must not loop
+	token, refreshes = "stale", 0
+	client = axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.GetInterceptorManager().AddInterceptor(axios.NewOAuth2Interceptor(tokenSource, func(ctx context.Context) error {
+		refreshes++
+		return nil
+	}))
+
+	_, err = client.Post(context.TODO(), server.URL, []byte("payload"))
+	var reqErr *axios.RequestError
+	assert.ErrorAs(t, err, &reqErr, "Request should fail with a status error")
+	assert.Equal(t, http.StatusUnauthorized, reqErr.StatusCode, "Status should be 401")
+	assert.Equal(t, 1, refreshes, "Token should be refreshed only once")
+
+	// The replay must not wait for the concurrency slot the rejected attempt held
+	token, refreshes = "stale", 0
+	client = axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetMaxConcurrency(1)
+	client.GetInterceptorManager().AddInterceptor(axios.NewOAuth2Interceptor(tokenSource, func(ctx context.Context) error {
+		refreshes++
+		token = "fresh"
+		return nil
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err = client.Post(ctx, server.URL, []byte("payload"))
+	assert.NoError(t, err, "Replay should succeed with a concurrency limit of 1")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be 200 OK")
+	assert.Equal(t, 1, refreshes, "Token should be refreshed once")
+
+	// A body that cannot be rewound is refused rather than replayed empty
+	token, refreshes = "stale", 0
+	_, err = client.Request(ctx, axios.Config{
+		Method:     http.MethodPost,
+		URL:        server.URL,
+		BodyReader: struct{ io.Reader }{strings.NewReader("payload")},
+	})
+	assert.ErrorIs(t, err, axios.ErrBodyNotReplayable, "Non-seekable body should not be replayed")
+	assert.Equal(t, 1, refreshes, "Token should still be refreshed for later requests")
+}
+
+// TestClientCookieJar verifies that session cookies are persisted and sent on later requests.
+func TestClientCookieJar(t *testing.T) {
+	// Mock server that sets a session cookie on /login and echoes it elsewhere
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123"})
+			return
+		}
+		cookie, err := r.Cookie("session")
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(cookie.Value))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	resp, err := client.Get(context.TODO(), server.URL+"/login")
+	assert.NoError(t, err, "Login should succeed")
+	assert.Len(t, resp.Cookies(), 1, "Response should expose the Set-Cookie header")
+	assert.Equal(t, "session", resp.Cookies()[0].Name, "Cookie name should match")
+
+	resp, err = client.Get(context.TODO(), server.URL+"/profile")
+	assert.NoError(t, err, "Session cookie should be sent automatically")
+	assert.Equal(t, "abc123", string(resp.Body), "Server should receive the session cookie")
+
+	// Disabling the jar stops sending cookies
+	client.SetCookieJar(nil)
+	_, err = client.Get(context.TODO(), server.URL+"/profile")
+	assert.Error(t, err, "Request without cookies should be rejected")
+}
+
+// TestClientRedirectPolicy verifies capping, disabling and customizing redirects.
+func TestClientRedirectPolicy(t *testing.T) {
+	// Mock server with a redirect chain /r/3 -> /r/2 -> /r/1 -> /r/0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/r/0" {
+			w.Write([]byte("final"))
+			return
+		}
+		var hops int
+		fmt.Sscanf(r.URL.Path, "/r/%d", &hops)
+		http.Redirect(w, r, fmt.Sprintf("/r/%d", hops-1), http.StatusFound)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// Within the cap the chain is followed
+	resp, err := client.Get(context.TODO(), server.URL+"/r/3", axios.Config{MaxRedirects: 3})
+	assert.NoError(t, err, "Request should follow redirects within the cap")
+	assert.Equal(t, "final", string(resp.Body), "Final response should be returned")
+
+	// Beyond the cap the request fails
+	_, err = client.Get(context.TODO(), server.URL+"/r/3", axios.Config{MaxRedirects: 2})
+	assert.ErrorIs(t, err, axios.ErrTooManyRedirects, "Request should fail beyond the cap")
+
+	// A negative cap returns the 3xx response
+	resp, err = client.Get(context.TODO(), server.URL+"/r/3", axios.Config{MaxRedirects: -1})
+	assert.NoError(t, err, "3xx response should not be an error")
+	assert.Equal(t, http.StatusFound, resp.StatusCode, "Redirect should not be followed")
+
+	// A custom policy can veto redirects
+	_, err = client.Get(context.TODO(), server.URL+"/r/3", axios.Config{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return fmt.Errorf("redirect to %s blocked", req.URL.Path)
+		},
+	})
+	assert.ErrorContains(t, err, "redirect to /r/2 blocked", "Custom policy should be applied")
+}
+
+// TestClientTrackRedirects verifies that followed redirects are recorded on the response when tracking is enabled.
+func TestClientTrackRedirects(t *testing.T) {
+	// Mock server with a redirect chain /a -> /b -> /c
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			http.Redirect(w, r, "/b", http.StatusFound)
+		case "/b":
+			http.Redirect(w, r, "/c", http.StatusMovedPermanently)
+		default:
+			w.Write([]byte("final"))
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// Each hop is recorded in order
+	resp, err := client.Get(context.TODO(), server.URL+"/a", axios.Config{TrackRedirects: true})
+	assert.NoError(t, err, "Request should follow redirects")
+	assert.Equal(t, "final", string(resp.Body), "Final response should be returned")
+	assert.Equal(t, []axios.Redirect{
+		{StatusCode: http.StatusFound, From: server.URL + "/a", To: server.URL + "/b"},
+		{StatusCode: http.StatusMovedPermanently, From: server.URL + "/b", To: server.URL + "/c"},
+	}, resp.Redirects, "Redirect history should list every hop")
+
+	// Vetoed redirects are not recorded
+	resp, err = client.Get(context.TODO(), server.URL+"/a", axios.Config{
+		TrackRedirects:  true,
+		FollowRedirects: new(bool),
+	})
+	assert.NoError(t, err, "3xx response should not be an error")
+	assert.Equal(t, http.StatusFound, resp.StatusCode, "Redirect should not be followed")
+	assert.Empty(t, resp.Redirects, "Unfollowed redirects should not be recorded")
+
+	// Without tracking no history is kept
+	resp, err = client.Get(context.TODO(), server.URL+"/a")
+	assert.NoError(t, err, "Request should follow redirects")
+	assert.Nil(t, resp.Redirects, "Redirect history should only be recorded when tracking is enabled")
+}
+
+// TestClientNoFollowRedirects verifies that a 3xx response is returned with its Location header when redirects are disabled.
+func TestClientNoFollowRedirects(t *testing.T) {
+	// Mock server that redirects /redirect to /final
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/final", http.StatusFound)
+			return
+		}
+		w.Write([]byte("final destination"))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	resp, err := client.Get(context.TODO(), server.URL+"/redirect", axios.Config{FollowRedirects: axios.Bool(false)})
+	assert.NoError(t, err, "3xx response should not be treated as an error")
+	assert.Equal(t, http.StatusFound, resp.StatusCode, "Redirect should not be followed")
+	assert.Equal(t, "/final", resp.Headers.Get("Location"), "Location header should be captured")
+	assert.Equal(t, server.URL+"/redirect", resp.FinalURL, "FinalURL should be the requested URL when not redirected")
+}
+
+// TestClientRedirectResendsBody verifies that 307 redirects resend in-memory bodies, even when upload progress wraps them.
+func TestClientRedirectResendsBody(t *testing.T) {
+	// Mock server that redirects /old to /new with 307, preserving the method and body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusTemporaryRedirect)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s %s", r.Method, r.URL.Path, body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	resp, err := client.Post(context.TODO(), server.URL+"/old", []byte("payload"))
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "POST /new payload", string(resp.Body), "Body should be resent to the redirect target")
+
+	var uploaded int64
+	var completed int
+	resp, err = client.Post(context.TODO(), server.URL+"/old", nil, axios.Config{
+		JSONBody: map[string]int{"id": 1},
+		OnUploadProgress: func(written, total int64) {
+			uploaded = written
+			if written == total {
+				completed++
+			}
+		},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, `POST /new {"id":1}`, string(resp.Body), "JSON body should be resent with progress reporting")
+	assert.Equal(t, int64(8), uploaded, "Progress should be reported for the resent body")
+	assert.Equal(t, 2, completed, "Both the original and the resent body should report progress")
+}
+
+// TestClientTLSOptions verifies custom CA pools and InsecureSkipVerify against a self-signed server.
+func TestClientTLSOptions(t *testing.T) {
+	// Mock TLS server with a self-signed certificate
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The default client rejects the self-signed certificate
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	_, err := client.Get(context.TODO(), server.URL)
+	assert.Error(t, err, "Self-signed certificate should be rejected by default")
+
+	// Trusting the server's certificate as a CA succeeds
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	pool, err := axios.LoadCertPool(caPEM)
+	assert.NoError(t, err, "Loading the CA should succeed")
+	client = axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{RootCAs: pool})
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed with the custom CA")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status should be 200 OK")
+
+	// Skipping verification succeeds
+	client = axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{InsecureSkipVerify: true})
+	_, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed when skipping verification")
+
+	// Malformed PEM is rejected
+	_, err = axios.LoadCertPool([]byte("not a certificate"))
+	assert.Error(t, err, "Invalid PEM should return an error")
+}
+
+// TestClientMutualTLS verifies that a client certificate loaded from PEM files is presented to the server.
+func TestClientMutualTLS(t *testing.T) {
+	// Mock TLS server that requires a client certificate
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// Generate a self-signed client certificate and write it to PEM files
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err, "Generating a key should succeed")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err, "Creating a certificate should succeed")
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err, "Marshaling the key should succeed")
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+
+	clientCert, err := axios.LoadClientCert(certFile, keyFile)
+	assert.NoError(t, err, "Loading the client certificate should succeed")
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{
+		ClientCert:         clientCert,
+		InsecureSkipVerify: true,
+	})
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed with a client certificate")
+	assert.Equal(t, "test-client", string(resp.Body), "Server should see the client certificate")
+
+	// A malformed key is rejected
+	os.WriteFile(keyFile, []byte("not a key"), 0o600)
+	_, err = axios.LoadClientCert(certFile, keyFile)
+	assert.Error(t, err, "Malformed key should return an error")
+}
+
+// TestClientValidateStatus verifies custom status validation and access to rejected responses.
+func TestClientValidateStatus(t *testing.T) {
+	// Mock server that returns 404 with a JSON error body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "not found"}`))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// By default the 404 is an error, but its response is still reachable
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.Nil(t, resp, "Response should be nil on error")
+	var reqErr *axios.RequestError
+	assert.ErrorAs(t, err, &reqErr, "404 should be a RequestError")
+	assert.NotNil(t, reqErr.Response, "Rejected response should be attached to the error")
+	assert.Equal(t, `{"error": "not found"}`, string(reqErr.Response.Body), "Rejected body should be readable")
+
+	// A custom validator accepts the 404
+	resp, err = client.Get(context.TODO(), server.URL, axios.Config{
+		ValidateStatus: func(statusCode int) bool { return statusCode < 500 },
+	})
+	assert.NoError(t, err, "404 should be accepted by the custom validator")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Status should be 404")
+
+	// NoStatusError returns the response for any status, even over a stricter validator
+	resp, err = client.Get(context.TODO(), server.URL, axios.Config{
+		NoStatusError:  true,
+		ValidateStatus: func(statusCode int) bool { return statusCode == http.StatusOK },
+	})
+	assert.NoError(t, err, "404 should not be an error with NoStatusError")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Status should be 404")
+	assert.Equal(t, `{"error": "not found"}`, string(resp.Body), "Error body should be returned in the response")
+}
+
+// TestRequestErrorResponse verifies that RequestError exposes the parsed error response.
+func TestRequestErrorResponse(t *testing.T) {
+	// Mock server that returns a structured JSON error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Error-Code", "E42")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "invalid title"}`))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	_, err := client.Get(context.TODO(), server.URL)
+
+	var reqErr *axios.RequestError
+	assert.ErrorAs(t, err, &reqErr, "Error should be a RequestError")
+	assert.Equal(t, http.StatusUnprocessableEntity, reqErr.Response.StatusCode, "Response status should be set")
+	assert.Equal(t, "422 Unprocessable Entity", reqErr.Response.Status, "Response status text should be set")
+	assert.Equal(t, "E42", reqErr.Response.Headers.Get("X-Error-Code"), "Response headers should be set")
+
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	assert.NoError(t, reqErr.Response.ParseJSON(&apiErr), "Error body should be decodable")
+	assert.Equal(t, "invalid title", apiErr.Message, "Error message should match")
+
+	// HandleResponseError populates the same structured response
+	httpResp, err := http.Get(server.URL)
+	assert.NoError(t, err, "Raw request should succeed")
+	defer httpResp.Body.Close()
+	handled := axios.HandleResponseError(httpResp)
+	assert.ErrorAs(t, handled, &reqErr, "HandleResponseError should return a RequestError")
+	assert.Equal(t, "422 Unprocessable Entity", reqErr.Response.Status, "Response should be populated")
+}
+
+// TestClientUserAgent verifies the default User-Agent and its overrides.
+func TestClientUserAgent(t *testing.T) {
+	// Mock server that echoes the User-Agent header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.UserAgent()))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, axios.DefaultUserAgent, string(resp.Body), "Default User-Agent should be sent")
+
+	resp, err = client.Get(context.TODO(), server.URL, axios.Config{UserAgent: "my-app/2.0"})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "my-app/2.0", string(resp.Body), "Config.UserAgent should override the default")
+
+	resp, err = client.Get(context.TODO(), server.URL, axios.Config{
+		UserAgent: "my-app/2.0",
+		Headers:   http.Header{"User-Agent": []string{"explicit/1.0"}},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "explicit/1.0", string(resp.Body), "Explicit header should win")
+}
+
+// TestInterceptorRequestValues verifies that interceptors can branch on per-request Config.Values.
+func TestInterceptorRequestValues(t *testing.T) {
+	// Mock server that echoes the Authorization and tenant headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Tenant") + "|" + r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.GetInterceptorManager().AddInterceptor(axios.Interceptor{
+		Request: func(req *http.Request) (*http.Request, error) {
+			if tenant, ok := axios.RequestValue(req, "tenant"); ok {
+				req.Header.Set("X-Tenant", tenant.(string))
+			}
+			if skip, _ := axios.RequestValue(req, "skipAuth"); skip != true {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			return req, nil
+		},
+	})
+
+	resp, err := client.Get(context.TODO(), server.URL, axios.Config{Values: map[string]interface{}{"tenant": "acme"}})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "acme|Bearer token", string(resp.Body), "Interceptor should read the tenant")
+
+	resp, err = client.Get(context.TODO(), server.URL, axios.Config{Values: map[string]interface{}{"skipAuth": true}})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "|", string(resp.Body), "Interceptor should skip auth")
+}
+
+// TestClientPaginate verifies that pages are followed through Link headers or a custom extractor until done.
+func TestClientPaginate(t *testing.T) {
+	// Mock server with three pages linked by Link headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		switch page {
+		case "1":
+			w.Header().Set("Link", `</items?page=2>; rel="next", </items?page=3>; rel="last"`)
+		case "2":
+			w.Header().Set("Link", `</items?page=1>; rel="prev", </items?page=3>; rel="next"`)
+		case "3":
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, BaseURL: server.URL}, nil)
+	config := axios.Config{Method: "GET", URL: "/items", Params: map[string]string{"page": "1"}}
+
+	var pages []string
+	for page, err := range client.Paginate(context.TODO(), config, axios.NextLink) {
+		assert.NoError(t, err, "Every page should succeed")
+		pages = append(pages, page.String())
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, pages, "All pages should be visited in order")
+
+	// Errors on a page stop the iteration
+	var errs []error
+	for _, err := range client.Paginate(context.TODO(), config, func(resp *axios.Response) (string, bool) {
+		return "/items?page=oops", false
+	}) {
+		errs = append(errs, err)
+	}
+	if assert.Len(t, errs, 2, "Iteration should stop after the failing page") {
+		assert.NoError(t, errs[0], "First page should succeed")
+		assert.Error(t, errs[1], "Failing page should yield its error")
+	}
+
+	// Breaking out of the loop stops requesting pages
+	count := 0
+	for range client.Paginate(context.TODO(), config, axios.NextLink) {
+		count++
+		break
+	}
+	assert.Equal(t, 1, count, "Loop should stop when the caller breaks")
+
+	// Path-absolute and relative links resolve against the page URL, not the base path
+	versioned := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/v1/items":
+			w.Header().Set("Link", `</v1/items?page=2>; rel="next"`)
+		case "/v1/items?page=2":
+			w.Header().Set("Link", `<items?page=3>; rel="next"`)
+		case "/v1/items?page=3":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte(r.URL.RequestURI()))
+	}))
+	defer versioned.Close()
+
+	client = axios.NewClient(axios.Config{Timeout: 10, BaseURL: versioned.URL + "/v1"}, nil)
+	pages = nil
+	for page, err := range client.Paginate(context.TODO(), axios.Config{URL: "/items"}, axios.NextLink) {
+		assert.NoError(t, err, "Every page should succeed")
+		if page != nil {
+			pages = append(pages, page.String())
+		}
+	}
+	assert.Equal(t, []string{"/v1/items", "/v1/items?page=2", "/v1/items?page=3"}, pages, "Links should resolve against the page URL")
+}
+
+// TestClientGraphQL verifies the GraphQL request envelope, data decoding and GraphQL-level errors.
+func TestClientGraphQL(t *testing.T) {
+	// Mock GraphQL server that reports an error for unknown users
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req), "Request should be a JSON envelope")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"), "Envelope should be sent as JSON")
+
+		if req.Variables["id"] == "1" {
+			w.Write([]byte(`{"data": {"user": {"name": "Ada"}}}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"user": null}, "errors": [{"message": "user not found", "path": ["user"]}]}`))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	query := `query($id: ID!) { user(id: $id) { name } }`
+
+	var out struct {
+		User *struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}
+	err := client.GraphQL(context.TODO(), server.URL, query, map[string]interface{}{"id": "1"}, &out)
+	assert.NoError(t, err, "Query should succeed")
+	if assert.NotNil(t, out.User, "Data should be decoded") {
+		assert.Equal(t, "Ada", out.User.Name, "Data should be decoded")
+	}
+
+	err = client.GraphQL(context.TODO(), server.URL, query, map[string]interface{}{"id": "2"}, &out)
+	var gqlErrs axios.GraphQLErrors
+	if assert.ErrorAs(t, err, &gqlErrs, "GraphQL errors should be returned distinctly") {
+		assert.Equal(t, "user not found", gqlErrs[0].Message, "Error message should be decoded")
+	}
+	_, isStatus := axios.IsStatusError(err)
+	assert.False(t, isStatus, "GraphQL errors should not be status errors")
+}
+
+// TestClientWith verifies that derived clients override defaults and interceptors without affecting the original.
+func TestClientWith(t *testing.T) {
+	// Mock server that echoes the tenant and trace headers and the path
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s|%s|%s", r.Header.Get("X-Tenant"), r.Header.Get("X-Trace"), r.URL.Path)
+	}))
+	defer server.Close()
+
+	base := axios.NewClient(axios.Config{
+		Timeout: 10,
+		BaseURL: server.URL,
+		Headers: http.Header{"X-Tenant": {"none"}},
+	}, nil)
+	base.GetInterceptorManager().AddRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		req.Header.Set("X-Trace", "base")
+		return req, nil
+	})
+
+	tenant := base.With(axios.Config{
+		BaseURL: server.URL + "/tenants/a",
+		Headers: http.Header{"X-Tenant": {"a"}},
+	})
+	tenant.GetInterceptorManager().AddRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		req.Header.Set("X-Trace", req.Header.Get("X-Trace")+"+tenant")
+		return req, nil
+	})
+
+	resp, err := tenant.Get(context.TODO(), "/items")
+	assert.NoError(t, err, "Tenant request should succeed")
+	assert.Equal(t, "a|base+tenant|/tenants/a/items", resp.String(), "Tenant client should apply its overrides and inherited interceptors")
+
+	resp, err = base.Get(context.TODO(), "/items")
+	assert.NoError(t, err, "Base request should succeed")
+	assert.Equal(t, "none|base|/items", resp.String(), "Base client should be unaffected")
+
+	assert.Same(t, base.HTTPClient().Transport, tenant.HTTPClient().Transport, "Clients should share the connection pool")
+	tenant.SetTransport(http.DefaultTransport)
+	assert.NotSame(t, base.HTTPClient().Transport, tenant.HTTPClient().Transport, "Replacing the clone's transport should not affect the base")
+}
+
+// TestClientToCurl verifies that a request config is rendered as an equivalent, safely quoted curl command.
+func TestClientToCurl(t *testing.T) {
+	client := axios.NewClient(axios.Config{
+		BaseURL:     "https://api.example.com",
+		BearerToken: "token",
+		UserAgent:   "test-agent",
+	}, nil)
+
+	command, err := client.ToCurl(axios.Config{
+		Method:     http.MethodPost,
+		URL:        "/users/{id}",
+		PathParams: map[string]string{"id": "42"},
+		Params:     map[string]string{"q": "it's"},
+		JSONBody:   map[string]string{"name": "O'Brien"},
+	})
+	assert.NoError(t, err, "Rendering should succeed")
+	assert.Equal(t, `curl -X 'POST' 'https://api.example.com/users/42?q=it%27s' -L`+
+		` -H 'Authorization: Bearer token' -H 'Content-Type: application/json' -H 'User-Agent: test-agent'`+
+		` --data-binary '{"name":"O'\''Brien"}'`, command, "Command should include method, URL, headers and body")
+
+	command, err = client.ToCurl(axios.Config{URL: "/blob", Body: []byte{0xff, 'a', '\''}, FollowRedirects: new(bool)})
+	assert.NoError(t, err, "Rendering a binary body should succeed")
+	assert.True(t, strings.HasPrefix(command, `printf '\377a'\''' | curl 'https://api.example.com/blob' -H`),
+		"Binary body should be piped in through printf, and GET should omit -X and -L when redirects are off")
+	assert.True(t, strings.HasSuffix(command, "--data-binary @-"), "Binary body should be read from stdin")
+
+	command, err = client.ToCurl(axios.Config{
+		Method: http.MethodPost,
+		URL:    "/upload",
+		Fields: map[string]string{"title": "report"},
+		Files:  []axios.FormFile{{FieldName: "file", FileName: "report.pdf", Reader: strings.NewReader("%PDF")}},
+	})
+	assert.NoError(t, err, "Rendering a multipart body should succeed")
+	assert.True(t, strings.HasSuffix(command, `--form-string 'title=report' -F 'file=@report.pdf'`), "Multipart body should use form options")
+	assert.NotContains(t, command, "Content-Type", "curl should set the multipart Content-Type itself")
+
+	_, err = client.ToCurl(axios.Config{URL: "/users/{id}", PathParams: map[string]string{"name": "ada"}})
+	assert.Error(t, err, "Missing path parameters should fail")
+}
+
+// TestClientSetDebug verifies that requests and responses are dumped with credentials redacted and large bodies truncated.
+func TestClientSetDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-secret"})
+		w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer server.Close()
+
+	var dump strings.Builder
+	client := axios.NewClient(axios.Config{BaseURL: server.URL, BearerToken: "token-secret"}, nil)
+	client.SetDebug(&dump, &axios.DebugOptions{MaxBodyBytes: 10})
+
+	_, err := client.Post(context.TODO(), "/items", []byte("hello"))
+	assert.NoError(t, err, "Request should succeed")
+	output := dump.String()
+	assert.Contains(t, output, ">>> POST /items HTTP/1.1", "Request line should be dumped")
+	assert.Contains(t, output, "Authorization: REDACTED", "Authorization should be redacted")
+	assert.Contains(t, output, "hello", "Request body should be dumped")
+	assert.Contains(t, output, "<<< HTTP/1.1 200 OK", "Status line should be dumped")
+	assert.Contains(t, output, "Set-Cookie: REDACTED", "Set-Cookie should be redacted")
+	assert.Contains(t, output, "xxxxxxxxxx\n[truncated, 90 more bytes]", "Large bodies should be truncated")
+	assert.NotContains(t, output, "secret", "No credentials should be written")
+
+	dump.Reset()
+	client.SetDebug(nil, nil)
+	_, err = client.Get(context.TODO(), "/items")
+	assert.NoError(t, err, "Request should succeed")
+	assert.Empty(t, dump.String(), "Nothing should be written once debugging is disabled")
+}
+
+// TestRequestBuilder verifies that a custom request builder replaces request construction while config still applies.
+func TestRequestBuilder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s %s %s %s", r.Method, r.Host, r.URL, r.Header.Get("Authorization"), body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{BaseURL: server.URL, BearerToken: "token"}, nil)
+	var builtURL string
+	resp, err := client.Request(context.TODO(), axios.Config{
+		Method:     http.MethodPut,
+		URL:        "/items/{id}",
+		PathParams: map[string]string{"id": "7"},
+		Params:     map[string]string{"v": "1"},
+		Body:       []byte("ignored"),
+		RequestBuilder: func(ctx context.Context, config axios.Config) (*http.Request, error) {
+			builtURL = config.URL
+			req, err := http.NewRequestWithContext(ctx, config.Method, config.URL, strings.NewReader("custom"))
+			if err != nil {
+				return nil, err
+			}
+			req.Host = "virtual.example.com"
+			return req, nil
+		},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, server.URL+"/items/7?v=1", builtURL, "Builder should receive the resolved URL")
+	assert.Equal(t, "PUT virtual.example.com /items/7?v=1 Bearer token custom", resp.String(),
+		"Builder's request should be sent with credentials applied")
+
+	_, err = client.Request(context.TODO(), axios.Config{
+		URL: "/items",
+		RequestBuilder: func(context.Context, axios.Config) (*http.Request, error) {
+			return nil, errors.New("boom")
+		},
+	})
+	assert.ErrorContains(t, err, "building request: boom", "Builder errors should be returned")
+}
+
+// TestClientMethodHelpers verifies every method helper against a server, including HEAD and OPTIONS specifics.
+func TestClientMethodHelpers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		switch r.Method {
+		case http.MethodOptions:
+			// CORS preflight answer
+			w.Header().Set("Allow", "GET, POST, PATCH, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"method":%q,"body":%q}`, r.Method, body)
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{BaseURL: server.URL}, nil)
+	ctx := context.TODO()
+	payload := []byte("data")
+
+	type echo struct{ Method, Body string }
+	for _, tc := range []struct {
+		method string
+		send   func(config axios.Config) (*axios.Response, error)
+		body   string
+	}{
+		{http.MethodGet, func(c axios.Config) (*axios.Response, error) { return client.Get(ctx, "/", c) }, ""},
+		{http.MethodPost, func(c axios.Config) (*axios.Response, error) { return client.Post(ctx, "/", payload, c) }, "data"},
+		{http.MethodPut, func(c axios.Config) (*axios.Response, error) { return client.Put(ctx, "/", payload, c) }, "data"},
+		{http.MethodPatch, func(c axios.Config) (*axios.Response, error) { return client.Patch(ctx, "/", payload, c) }, "data"},
+		{http.MethodDelete, func(c axios.Config) (*axios.Response, error) { return client.Delete(ctx, "/", c) }, ""},
+	} {
+		var result echo
+		resp, err := tc.send(axios.Config{Result: &result})
+		assert.NoError(t, err, "%s request should succeed", tc.method)
+		assert.Equal(t, tc.method, resp.Headers.Get("X-Method"), "%s should be sent with its method", tc.method)
+		assert.Equal(t, echo{tc.method, tc.body}, result, "%s response should be decoded", tc.method)
+	}
+
+	// HEAD has no body to read or decode, but its headers are still populated
+	var result echo
+	resp, err := client.Head(ctx, "/", axios.Config{Result: &result})
+	assert.NoError(t, err, "HEAD should not fail on the empty body")
+	assert.Empty(t, resp.Body, "HEAD response should have no body")
+	assert.Equal(t, echo{}, result, "HEAD should leave Result untouched")
+	assert.Equal(t, "HEAD", resp.Headers.Get("X-Method"), "HEAD response headers should be populated")
+	assert.Equal(t, "application/json", resp.Headers.Get("Content-Type"), "HEAD should report the GET content type")
+	assert.NotEmpty(t, resp.Headers.Get("Content-Length"), "HEAD should report the GET content length")
+
+	resp, err = client.Head(ctx, "/", axios.Config{Stream: true})
+	assert.NoError(t, err, "Streamed HEAD should succeed")
+	assert.NoError(t, resp.RawBody.Close(), "Streamed HEAD body should close cleanly")
+
+	// OPTIONS can inspect a CORS preflight
+	resp, err = client.Options(ctx, "/", axios.Config{Headers: http.Header{
+		"Origin":                        {"https://app.example.com"},
+		"Access-Control-Request-Method": {"PATCH"},
+	}})
+	assert.NoError(t, err, "OPTIONS should succeed")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode, "Preflight status should be returned")
+	assert.Equal(t, "GET, POST, PATCH, OPTIONS", resp.Headers.Get("Allow"), "Allow header should be returned")
+	assert.Equal(t, "https://app.example.com", resp.Headers.Get("Access-Control-Allow-Origin"), "CORS headers should be returned")
+}
+
+// TestClientInvalidURL verifies that empty and malformed URLs fail up front with ErrInvalidURL naming the value.
+func TestClientInvalidURL(t *testing.T) {
+	client := axios.NewClient(axios.Config{}, nil)
+	for _, tc := range []struct {
+		config axios.Config
+		want   string
+	}{
+		{axios.Config{}, `invalid URL "": URL is empty`},
+		{axios.Config{URL: "api.example.com/users"}, `invalid URL "api.example.com/users": missing scheme`},
+		{axios.Config{URL: "https:///users"}, `invalid URL "https:///users": missing host`},
+		{axios.Config{URL: "http://[::1/users"}, `invalid URL "http://[::1/users": missing ']' in host`},
+		{axios.Config{BaseURL: "http://[::1", URL: "/users"}, `base URL: invalid URL "http://[::1"`},
+	} {
+		_, err := client.Request(context.TODO(), tc.config)
+		assert.ErrorIs(t, err, axios.ErrInvalidURL, "%q should be rejected", tc.config.URL)
+		assert.ErrorContains(t, err, tc.want, "Error should name the offending value")
+	}
+}
+
+// TestClientMethodNormalization verifies that an empty method defaults to GET and methods are uppercased.
+func TestClientMethodNormalization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{}, nil)
+	resp, err := client.Request(context.TODO(), axios.Config{URL: server.URL})
+	assert.NoError(t, err, "Request without a method should succeed")
+	assert.Equal(t, "GET", resp.String(), "Empty method should default to GET")
+
+	resp, err = client.Request(context.TODO(), axios.Config{Method: "patch", URL: server.URL})
+	assert.NoError(t, err, "Request with a lowercase method should succeed")
+	assert.Equal(t, "PATCH", resp.String(), "Method should be uppercased")
+
+	command, err := client.ToCurl(axios.Config{Method: "delete", URL: server.URL})
+	assert.NoError(t, err, "Rendering should succeed")
+	assert.True(t, strings.HasPrefix(command, "curl -X 'DELETE'"), "curl command should use the uppercased method")
+}
+
+// TestClientExplicitAcceptEncoding verifies that bodies compressed because Accept-Encoding was set explicitly are decoded.
+func TestClientExplicitAcceptEncoding(t *testing.T) {
+	payload := strings.Repeat("compressible ", 100)
+
+	// Mock server that compresses whenever the client accepts it
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write([]byte(payload))
+			return
+		}
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		gz.Write([]byte(payload))
+		gz.Close()
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Length", strconv.Itoa(compressed.Len()))
+		w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{BaseURL: server.URL}, nil)
+	explicit := axios.Config{Headers: http.Header{"Accept-Encoding": {"gzip"}}}
+
+	// Go negotiates and decodes compression itself when the header is not set
+	auto, err := client.Get(context.TODO(), "/")
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, payload, auto.String(), "Transport should decode the body")
+
+	// Setting the header explicitly gives the same decoded body and headers
+	resp, err := client.Get(context.TODO(), "/", explicit)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, payload, resp.String(), "Body should be decompressed")
+	assert.Empty(t, resp.Headers.Get("Content-Encoding"), "Content-Encoding should be removed")
+	assert.Empty(t, resp.Headers.Get("Content-Length"), "Compressed Content-Length should be removed")
+
+	// Streams are decoded as they are read, and the size limit applies to the decoded body
+	explicit.Stream = true
+	stream, err := client.Request(context.TODO(), explicit)
+	assert.NoError(t, err, "Stream should open")
+	body, err := io.ReadAll(stream.RawBody)
+	stream.RawBody.Close()
+	assert.NoError(t, err, "Reading the stream should succeed")
+	assert.Equal(t, payload, string(body), "Stream should be decompressed")
+
+	explicit.Stream = false
+	explicit.MaxResponseBytes = 100
+	_, err = client.Request(context.TODO(), explicit)
+	assert.ErrorIs(t, err, axios.ErrResponseTooLarge, "Limit should apply to the decompressed size")
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestClientResponseWriter verifies that successful bodies are copied into the writer instead of being buffered.
+func TestClientResponseWriter(t *testing.T) {
+	// Mock server that fails /missing, hangs after part of /hang and counts requests
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/missing":
+			http.Error(w, "not here", http.StatusNotFound)
+		case "/hang":
+			w.Write([]byte("partial"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		default:
+			w.Write([]byte("hello world"))
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// The body goes to the writer only
+	var buf bytes.Buffer
+	resp, err := client.Get(context.TODO(), server.URL, axios.Config{ResponseWriter: &buf})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "hello world", buf.String(), "Body should be written to the writer")
+	assert.Nil(t, resp.Body, "Body should not be buffered")
+	assert.Equal(t, int64(11), resp.ContentLength, "ContentLength should count the bytes written")
+
+	// Error bodies are buffered for the error instead
+	buf.Reset()
+	_, err = client.Get(context.TODO(), server.URL+"/missing", axios.Config{ResponseWriter: &buf})
+	reqErr, ok := axios.IsStatusError(err)
+	assert.True(t, ok, "404 should be a status error")
+	assert.Contains(t, string(reqErr.Response.Body), "not here", "Error body should be buffered")
+	assert.Empty(t, buf.String(), "Error body should not be written")
+
+	// Cancellation stops the copy
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	buf.Reset()
+	_, err = client.Get(ctx, server.URL+"/hang", axios.Config{ResponseWriter: &buf})
+	assert.True(t, axios.IsCanceled(err), "Canceling should abort the copy")
+
+	// A failed write is not retried
+	requests.Store(0)
+	_, err = client.Get(context.TODO(), server.URL, axios.Config{
+		ResponseWriter: failingWriter{},
+		Retry:          &axios.RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond},
+	})
+	assert.ErrorContains(t, err, "disk full", "Write error should be returned")
+	assert.Equal(t, int32(1), requests.Load(), "Partially written responses should not be retried")
+}
+
+// TestClientBodyReadTimeout verifies that a slow body fails with its own timeout error while a slow header wait does not count.
+func TestClientBodyReadTimeout(t *testing.T) {
+	// Mock server that trickles /slow-body and delays the headers of /slow-headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow-headers" {
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte("done"))
+			return
+		}
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// A body slower than the limit fails fast with a distinct error
+	start := time.Now()
+	_, err := client.Get(context.TODO(), server.URL+"/slow-body", axios.Config{BodyReadTimeout: 50 * time.Millisecond})
+	assert.ErrorIs(t, err, axios.ErrBodyReadTimeout, "Slow body should time out")
+	assert.True(t, axios.IsTimeout(err), "Body read timeout should count as a timeout")
+	assert.Less(t, time.Since(start), time.Second, "Body read timeout should not wait for the request timeout")
+
+	// Waiting for the headers does not count against the limit
+	resp, err := client.Get(context.TODO(), server.URL+"/slow-headers", axios.Config{BodyReadTimeout: 50 * time.Millisecond})
+	assert.NoError(t, err, "Slow headers should not trip the body read timeout")
+	assert.Equal(t, "done", string(resp.Body), "Body should be read in time")
+}
+
+// TestPackageLevelHelpers verifies that the package-level helpers send requests with DefaultClient.
+func TestPackageLevelHelpers(t *testing.T) {
+	// Mock server that echoes the method and body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s", r.Method, body)
+	}))
+	defer server.Close()
+
+	// The default client is created on first use
+	resp, err := axios.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "GET should succeed")
+	assert.Equal(t, "GET ", string(resp.Body), "GET should reach the server")
+	assert.NotNil(t, axios.DefaultClient, "DefaultClient should be created on first use")
+
+	resp, err = axios.Post(context.TODO(), server.URL, []byte("payload"))
+	assert.NoError(t, err, "POST should succeed")
+	assert.Equal(t, "POST payload", string(resp.Body), "POST should send the body")
+
+	// Replacing DefaultClient configures the helpers
+	original := axios.DefaultClient
+	defer func() { axios.DefaultClient = original }()
+	axios.DefaultClient = axios.NewClient(axios.Config{BaseURL: server.URL}, nil)
+	resp, err = axios.Delete(context.TODO(), "/item")
+	assert.NoError(t, err, "DELETE should succeed")
+	assert.Equal(t, "DELETE ", string(resp.Body), "Relative URL should resolve against the replaced client's base URL")
+}
+
+// TestConfigValidate verifies that configuration mistakes are reported together and before any request is sent.
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, axios.Config{URL: "https://api.example.com/users"}.Validate(), "Valid config should pass")
+	assert.NoError(t, axios.Config{BaseURL: "https://api.example.com", URL: "/users", Method: "propfind"}.Validate(), "Custom methods and relative URLs should pass")
+
+	for _, tc := range []struct {
+		config axios.Config
+		target error
+		want   string
+	}{
+		{axios.Config{}, axios.ErrInvalidURL, "URL is empty"},
+		{axios.Config{URL: "https://api.example.com", Method: "GET /"}, axios.ErrInvalidConfig, `invalid method "GET /"`},
+		{axios.Config{URL: "https://api.example.com", RequestTimeout: -time.Second}, axios.ErrInvalidConfig, "negative timeout"},
+		{axios.Config{URL: "https://api.example.com", Body: []byte("a"), JSONBody: 1}, axios.ErrConflictingBody, "conflicting request body fields"},
+		{axios.Config{URL: "https://api.example.com", MsgpackBody: 1}, axios.ErrNoCodec, "MsgpackBody without MsgpackCodec"},
+	} {
+		err := tc.config.Validate()
+		assert.ErrorIs(t, err, axios.ErrInvalidConfig, "%q should be reported as an invalid config", tc.want)
+		assert.ErrorIs(t, err, tc.target, "%q should match its specific error", tc.want)
+		assert.ErrorContains(t, err, tc.want, "Error should describe the problem")
+	}
+
+	// Every problem is reported at once
+	err := axios.Config{Method: "BAD METHOD", Timeout: -1}.Validate()
+	assert.ErrorIs(t, err, axios.ErrInvalidURL, "Missing URL should be reported")
+	assert.ErrorContains(t, err, "invalid method", "Invalid method should be reported alongside")
+	assert.ErrorContains(t, err, "negative timeout", "Negative timeout should be reported alongside")
+
+	// Request fails without contacting the server
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+	client := axios.NewClient(axios.Config{}, nil)
+	_, err = client.Post(context.TODO(), server.URL, []byte("a"), axios.Config{Form: url.Values{"b": {"c"}}})
+	assert.ErrorIs(t, err, axios.ErrConflictingBody, "Conflicting bodies should be rejected")
+	assert.Zero(t, requests.Load(), "Invalid config should not reach the server")
+}
+
+// TestClientBodyOnGet verifies that bodies on GET and DELETE requests reach the server intact and bypass the cache.
+func TestClientBodyOnGet(t *testing.T) {
+	// Mock server that echoes the method, Content-Type and body, and allows caching
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Cache-Control", "max-age=60")
+		fmt.Fprintf(w, "%s %s %s", r.Method, r.Header.Get("Content-Type"), body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetCache(axios.NewMemoryCache(nil))
+
+	query := map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}}
+	resp, err := client.Get(context.TODO(), server.URL+"/_search", axios.Config{JSONBody: query})
+	assert.NoError(t, err, "GET with a body should succeed")
+	assert.Equal(t, `GET application/json {"query":{"match_all":{}}}`, string(resp.Body), "GET body should reach the server intact")
+
+	// A different body on the same URL is not answered from the cache
+	resp, err = client.Get(context.TODO(), server.URL+"/_search", axios.Config{JSONBody: map[string]int{"size": 1}})
+	assert.NoError(t, err, "GET with a body should succeed")
+	assert.Equal(t, `GET application/json {"size":1}`, string(resp.Body), "GET with a body should not be cached")
+
+	resp, err = client.Delete(context.TODO(), server.URL+"/_query", axios.Config{JSONBody: query})
+	assert.NoError(t, err, "DELETE with a body should succeed")
+	assert.Equal(t, `DELETE application/json {"query":{"match_all":{}}}`, string(resp.Body), "DELETE body should reach the server intact")
+}
+
+// TestClientRequestStreamDeadline verifies that a stream whose context expires on another goroutine is closed without racing the caller.
+func TestClientRequestStreamDeadline(t *testing.T) {
+	// Mock server that sends the headers, then never finishes the body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("start"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	for i := 0; i < 20; i++ {
+		// The deadline fires on a timer goroutine, possibly while the stream is being set up
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(i)*time.Millisecond)
+		resp, err := client.RequestStream(ctx, axios.Config{URL: server.URL})
+		if err == nil {
+			<-ctx.Done()
+			_, err = io.ReadAll(resp.RawBody)
+			assert.Error(t, err, "Reading an expired stream should fail")
+			assert.NoError(t, resp.RawBody.Close(), "Closing an expired stream should be a no-op")
+		}
+		cancel()
+	}
 }
@@ -0,0 +1,190 @@
+package axios_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClientCache verifies revalidation with ETags, max-age freshness, no-store and how cached responses are flagged.
+func TestClientCache(t *testing.T) {
+	// Mock server with a revalidated, a fresh and an uncacheable resource
+	var hits, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		switch r.URL.Path {
+		case "/etag":
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/fresh":
+			w.Header().Set("Cache-Control", "max-age=60")
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store")
+			w.Header().Set("ETag", `"v1"`)
+		}
+		w.Write([]byte("payload " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetCache(axios.NewMemoryCache(nil))
+
+	// Stale entries are revalidated and served from the cache on 304
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(context.TODO(), server.URL+"/etag")
+		assert.NoError(t, err, "Request should succeed")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Cached response should keep its status")
+		assert.Equal(t, "payload /etag", resp.String(), "Body should be served from the cache")
+		assert.Equal(t, i > 0, resp.FromCache, "Only the revalidated response should come from the cache")
+		assert.Equal(t, i > 0, resp.Revalidated, "Only the second request should be revalidated")
+	}
+	assert.Equal(t, int32(1), notModified.Load(), "Second request should be revalidated")
+
+	// Fresh entries are served without contacting the server
+	hits.Store(0)
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(context.TODO(), server.URL+"/fresh")
+		assert.NoError(t, err, "Request should succeed")
+		assert.Equal(t, "payload /fresh", resp.String(), "Fresh body should be served")
+		assert.Equal(t, i > 0, resp.FromCache, "Repeated requests should be cache hits")
+		assert.False(t, resp.Revalidated, "Fresh hits should not be revalidated")
+	}
+	assert.Equal(t, int32(1), hits.Load(), "Fresh entry should not be requested again")
+
+	// no-store responses are never cached
+	hits.Store(0)
+	client.Get(context.TODO(), server.URL+"/no-store")
+	client.Get(context.TODO(), server.URL+"/no-store")
+	assert.Equal(t, int32(2), hits.Load(), "no-store responses should always be fetched")
+
+	// Only GET requests are cached
+	hits.Store(0)
+	client.Post(context.TODO(), server.URL+"/fresh", nil)
+	assert.Equal(t, int32(1), hits.Load(), "POST should bypass the cache")
+}
+
+// TestClientCacheCredentials verifies that responses are not shared across credentials or request headers they vary on.
+func TestClientCacheCredentials(t *testing.T) {
+	// Mock server with fresh resources that echo the caller's credentials and language
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		switch r.URL.Path {
+		case "/private":
+			w.Header().Set("Cache-Control", "private, max-age=60")
+		case "/vary":
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Vary", "Accept-Language")
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "a"})
+		default:
+			w.Header().Set("Cache-Control", "max-age=60")
+		}
+		w.Write([]byte(r.Header.Get("Authorization") + r.Header.Get("Accept-Language")))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetCache(axios.NewMemoryCache(nil))
+
+	// Each bearer token gets its own response
+	for _, token := range []string{"a", "b"} {
+		resp, err := client.Get(context.TODO(), server.URL+"/fresh", axios.Config{BearerToken: token})
+		assert.NoError(t, err, "Request should succeed")
+		assert.Equal(t, "Bearer "+token, resp.String(), "Response should be for the caller's token")
+		assert.False(t, resp.FromCache, "Authenticated responses should not be cached")
+	}
+	assert.Equal(t, int32(2), hits.Load(), "Each token should reach the server")
+
+	// private responses are never stored
+	hits.Store(0)
+	client.Get(context.TODO(), server.URL+"/private")
+	client.Get(context.TODO(), server.URL+"/private")
+	assert.Equal(t, int32(2), hits.Load(), "private responses should always be fetched")
+
+	// Entries are only served to requests with the same values for the Vary headers
+	hits.Store(0)
+	for _, language := range []string{"en", "de", "de"} {
+		resp, err := client.Get(context.TODO(), server.URL+"/vary", axios.Config{
+			Headers: http.Header{"Accept-Language": []string{language}},
+		})
+		assert.NoError(t, err, "Request should succeed")
+		assert.Equal(t, language, resp.String(), "Response should match the requested language")
+	}
+	assert.Equal(t, int32(2), hits.Load(), "Only the repeated language should be a cache hit")
+
+	// Once the jar holds cookies for the host, responses are private to the session
+	hits.Store(0)
+	client.Get(context.TODO(), server.URL+"/login")
+	client.Get(context.TODO(), server.URL+"/other")
+	client.Get(context.TODO(), server.URL+"/other")
+	assert.Equal(t, int32(3), hits.Load(), "Requests sending cookies should bypass the cache")
+}
+
+// TestMemoryCacheLimits verifies that the least recently used entries are evicted beyond the entry and byte limits.
+func TestMemoryCacheLimits(t *testing.T) {
+	cache := axios.NewMemoryCache(&axios.MemoryCacheOptions{MaxEntries: 2, MaxBytes: 10})
+	cache.Set("a", &axios.CacheEntry{Body: []byte("1")})
+	cache.Set("b", &axios.CacheEntry{Body: []byte("2")})
+	cache.Get("a")
+	cache.Set("c", &axios.CacheEntry{Body: []byte("3")})
+	assert.Equal(t, 2, cache.Len(), "Cache should hold at most MaxEntries entries")
+	_, ok := cache.Get("b")
+	assert.False(t, ok, "Least recently used entry should be evicted")
+	_, ok = cache.Get("a")
+	assert.True(t, ok, "Recently used entry should be kept")
+
+	cache.Set("d", &axios.CacheEntry{Body: []byte("1234567890")})
+	assert.Equal(t, 1, cache.Len(), "Entries should be evicted to stay within MaxBytes")
+	cache.Set("e", &axios.CacheEntry{Body: []byte("12345678901")})
+	_, ok = cache.Get("e")
+	assert.False(t, ok, "Entries larger than MaxBytes should not be stored")
+}
+
+// TestClientCacheLargeBodies verifies that large responses and those copied to a ResponseWriter pass through uncached.
+func TestClientCacheLargeBodies(t *testing.T) {
+	large := bytes.Repeat([]byte("x"), 9<<20)
+
+	// Mock server with a fresh small and a fresh large resource, the latter without a Content-Length
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		if r.URL.Path == "/large" {
+			w.(http.Flusher).Flush()
+			w.Write(large)
+			return
+		}
+		w.Write([]byte("small"))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetCache(axios.NewMemoryCache(nil))
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(context.TODO(), server.URL+"/large")
+		assert.NoError(t, err, "Request should succeed")
+		assert.Equal(t, len(large), len(resp.Body), "Large body should be delivered whole")
+	}
+	assert.Equal(t, int32(2), hits.Load(), "Large responses should not be cached")
+
+	hits.Store(0)
+	for i := 0; i < 2; i++ {
+		var written bytes.Buffer
+		_, err := client.Get(context.TODO(), server.URL+"/small", axios.Config{ResponseWriter: &written})
+		assert.NoError(t, err, "Request should succeed")
+		assert.Equal(t, "small", written.String(), "Body should be written")
+	}
+	assert.Equal(t, int32(2), hits.Load(), "Responses copied to a ResponseWriter should not be cached")
+}
@@ -0,0 +1,476 @@
+package axios_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInterceptorRemoveAndClear verifies that interceptors can be removed by ID or cleared entirely.
+func TestInterceptorRemoveAndClear(t *testing.T) {
+	// Mock server that echoes the Authorization and tracing headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization") + "|" + r.Header.Get("X-Trace")))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	im := client.GetInterceptorManager()
+
+	authID := im.AddInterceptor(axios.BearerAuthInterceptor("token"))
+	im.AddInterceptor(axios.Interceptor{
+		Request: func(req *http.Request) (*http.Request, error) {
+			req.Header.Set("X-Trace", "on")
+			return req, nil
+		},
+	})
+
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "Bearer token|on", string(resp.Body), "Both interceptors should run")
+
+	// Removing the auth interceptor keeps the other one
+	assert.True(t, im.RemoveInterceptor(authID), "Interceptor should be removed")
+	assert.False(t, im.RemoveInterceptor(authID), "Removing twice should report not found")
+	resp, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "|on", string(resp.Body), "Only the remaining interceptor should run")
+
+	// Clearing removes everything
+	im.ClearInterceptors()
+	resp, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "|", string(resp.Body), "No interceptors should run")
+}
+
+// TestInterceptorSeparateChains verifies that request and response interceptors registered separately run in their own order.
+func TestInterceptorSeparateChains(t *testing.T) {
+	// Mock server that echoes the X-Order header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Order")))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	im := client.GetInterceptorManager()
+
+	// Interleave registrations; each chain keeps its own order
+	im.AddResponseInterceptor(func(resp *axios.Response) (*axios.Response, error) {
+		resp.Body = append(resp.Body, []byte(",resp1")...)
+		return resp, nil
+	})
+	im.AddRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		req.Header.Set("X-Order", "req1")
+		return req, nil
+	})
+	im.AddResponseInterceptor(func(resp *axios.Response) (*axios.Response, error) {
+		resp.Body = append(resp.Body, []byte(",resp2")...)
+		return resp, nil
+	})
+	im.AddRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		req.Header.Set("X-Order", req.Header.Get("X-Order")+",req2")
+		return req, nil
+	})
+
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "req1,req2,resp1,resp2", string(resp.Body), "Each chain should run in registration order")
+
+	// Prepended interceptors run ahead of those already registered, so req1 replaces req0's header
+	im.PrependRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		req.Header.Set("X-Order", "req0")
+		return req, nil
+	})
+	im.PrependResponseInterceptor(func(resp *axios.Response) (*axios.Response, error) {
+		resp.Body = append(resp.Body, []byte(",resp0")...)
+		return resp, nil
+	})
+	id := im.PrependRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		return nil, errors.New("removed interceptor ran")
+	})
+	assert.True(t, im.RemoveInterceptor(id), "Prepended interceptor should be removable by its ID")
+
+	resp, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "req1,req2,resp0,resp1,resp2", string(resp.Body), "Prepended interceptors should run first")
+}
+
+// TestInterceptorNilFuncsSkipped verifies that request-only and response-only interceptors on the same manager don't panic.
+func TestInterceptorNilFuncsSkipped(t *testing.T) {
+	im := axios.NewInterceptorManager()
+	im.AddInterceptor(axios.Interceptor{
+		Response: func(resp *axios.Response) (*axios.Response, error) {
+			resp.StatusCode = http.StatusAccepted
+			return resp, nil
+		},
+	})
+	im.AddInterceptor(axios.Interceptor{
+		Request: func(req *http.Request) (*http.Request, error) {
+			req.Header.Set("X-Intercepted", "yes")
+			return req, nil
+		},
+	})
+	im.AddInterceptor(axios.Interceptor{}) // Neither direction
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.NotPanics(t, func() {
+		var err error
+		req, err = im.ApplyRequestInterceptors(req)
+		assert.NoError(t, err, "Request interceptors should not return an error")
+	}, "Response-only interceptors should be skipped for requests")
+	assert.Equal(t, "yes", req.Header.Get("X-Intercepted"), "Request interceptor should run")
+
+	resp := &axios.Response{StatusCode: http.StatusOK}
+	assert.NotPanics(t, func() {
+		var err error
+		resp, err = im.ApplyResponseInterceptors(resp)
+		assert.NoError(t, err, "Response interceptors should not return an error")
+	}, "Request-only interceptors should be skipped for responses")
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode, "Response interceptor should run")
+
+	// A client runs its request, response, replay and error chains past interceptors without those hooks
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.GetInterceptorManager().AddInterceptor(axios.Interceptor{})
+	client.GetInterceptorManager().AddErrorInterceptor(func(err error) error {
+		return fmt.Errorf("intercepted: %w", err)
+	})
+	assert.NotPanics(t, func() {
+		_, err := client.Get(context.TODO(), server.URL)
+		assert.ErrorContains(t, err, "intercepted", "Error interceptor should run")
+	}, "Interceptors without hooks should be skipped by every chain")
+}
+
+// apiError is a typed error produced by an error interceptor
+type apiError struct {
+	Code int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("api error %d", e.Code)
+}
+
+// TestInterceptorError verifies that error interceptors can observe and transform failures after retries.
+func TestInterceptorError(t *testing.T) {
+	// Mock server that always fails
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond},
+	}, nil)
+
+	// Count failures and convert status errors into a typed error
+	failures := 0
+	client.GetInterceptorManager().AddErrorInterceptor(func(err error) error {
+		failures++
+		if reqErr, ok := axios.IsStatusError(err); ok {
+			return &apiError{Code: reqErr.StatusCode}
+		}
+		return nil
+	})
+	// Returning nil leaves the error unchanged
+	client.GetInterceptorManager().AddErrorInterceptor(func(err error) error { return nil })
+
+	_, err := client.Get(context.TODO(), server.URL)
+	var typed *apiError
+	assert.ErrorAs(t, err, &typed, "Error should be transformed by the interceptor")
+	assert.Equal(t, http.StatusBadGateway, typed.Code, "Typed error should carry the status")
+	assert.Equal(t, 3, requests, "Request should be retried before the error interceptor runs")
+	assert.Equal(t, 1, failures, "Error interceptor should run once per failed request")
+}
+
+// TestWithSkipInterceptors verifies that a request's context can bypass all interceptors or only selected ones.
+func TestWithSkipInterceptors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(r.Header.Get("Authorization") + "|" + r.Header.Get("X-Trace")))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{BaseURL: server.URL}, nil)
+	manager := client.GetInterceptorManager()
+	authID := manager.AddRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		req.Header.Set("Authorization", "Bearer token")
+		return req, nil
+	})
+	manager.AddRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		req.Header.Set("X-Trace", "on")
+		return req, nil
+	})
+	responses := 0
+	manager.AddResponseInterceptor(func(resp *axios.Response) (*axios.Response, error) {
+		responses++
+		return resp, nil
+	})
+	errorsSeen := 0
+	manager.AddErrorInterceptor(func(err error) error {
+		errorsSeen++
+		return nil
+	})
+
+	resp, err := client.Get(context.Background(), "/")
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "Bearer token|on", string(resp.Body), "All request interceptors should run by default")
+	assert.Equal(t, 1, responses, "Response interceptor should run by default")
+
+	resp, err = client.Get(axios.WithSkipInterceptors(context.Background(), authID), "/")
+	assert.NoError(t, err, "Request skipping one interceptor should succeed")
+	assert.Equal(t, "|on", string(resp.Body), "Only the skipped interceptor should be bypassed")
+	assert.Equal(t, 2, responses, "Interceptors not listed should still run")
+
+	resp, err = client.Get(axios.WithSkipInterceptors(context.Background()), "/")
+	assert.NoError(t, err, "Request skipping all interceptors should succeed")
+	assert.Equal(t, "|", string(resp.Body), "No request interceptor should run when all are skipped")
+	assert.Equal(t, 2, responses, "No response interceptor should run when all are skipped")
+
+	_, err = client.Get(axios.WithSkipInterceptors(context.Background()), "/fail")
+	assert.Error(t, err, "Error status should still be returned")
+	assert.Equal(t, 0, errorsSeen, "No error interceptor should run when all are skipped")
+}
+
+// TestSigV4Signer verifies SigV4 signatures against the AWS test suite and that the interceptor signs request bodies.
+func TestSigV4Signer(t *testing.T) {
+	signer := &axios.SigV4Signer{
+		AccessKey: "AKIDEXAMPLE",
+		SecretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		Region:    "us-east-1",
+		Service:   "service",
+	}
+	signedAt := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	// get-vanilla from the AWS Signature Version 4 test suite
+	req, _ := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	assert.NoError(t, signer.Sign(req, signedAt), "Signing should succeed")
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"), "X-Amz-Date should be set")
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"), "Signature should match the AWS test suite")
+
+	// Query parameters are sorted by name, then value, even when one name prefixes another
+	for _, vector := range []struct{ query, signature string }{
+		{"Param2=value2&Param1=value1", "b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500"}, // get-vanilla-query-order-key-case
+		{"key2=x&key=y", "e0ad3658e8398ebb59d17056de1a25dab731cf8b852d1f1ebdb1989021287cb5"},
+		{"a-b=1&a=2", "3195c10f6c70f9392a7764f6f83099349c32cf39a12222f775fca70b6227a5a4"},
+	} {
+		req, _ := http.NewRequest("GET", "https://example.amazonaws.com/?"+vector.query, nil)
+		assert.NoError(t, signer.Sign(req, signedAt), "Signing should succeed")
+		assert.True(t, strings.HasSuffix(req.Header.Get("Authorization"), "Signature="+vector.signature),
+			"Signature for ?%s should match the reference", vector.query)
+	}
+
+	// Paths are normalized and encoded twice, except for S3, which keeps them as sent
+	s3Signer := *signer
+	s3Signer.Service = "s3"
+	for _, vector := range []struct {
+		signer          *axios.SigV4Signer
+		path, signature string
+	}{
+		{signer, "/example/..", "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"}, // get-relative
+		{signer, "/./", "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"},         // get-slash-dot-slash
+		{signer, "//example//", "9a624bd73a37c9a373b5312afbebe7a714a789de108f0bdfe846570885f57e84"}, // get-slashes
+		{signer, "/example/../foo//a%2Fb", "69df2c128b71f7957d105a65c04fd9117a204f331e1a4c6f54e5cca62ab52732"},
+		{&s3Signer, "/bucket/a%2Fb%20c", "806915b0a82f57ad61cb023b016a4e8de984376de24cc4096bc4b480474199b1"},
+	} {
+		req, _ := http.NewRequest("GET", "https://example.amazonaws.com"+vector.path, nil)
+		assert.NoError(t, vector.signer.Sign(req, signedAt), "Signing should succeed")
+		assert.True(t, strings.HasSuffix(req.Header.Get("Authorization"), "Signature="+vector.signature),
+			"Signature for %s should match the reference", vector.path)
+	}
+
+	// The interceptor hashes in-memory bodies into an S3 signature
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sum := sha256.Sum256([]byte("payload"))
+		assert.Equal(t, hex.EncodeToString(sum[:]), r.Header.Get("X-Amz-Content-Sha256"), "Body hash should be signed")
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/s3/aws4_request", "Request should be signed for S3")
+		assert.Contains(t, r.Header.Get("Authorization"), "SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-target,", "x-amz-* headers from the config should be signed")
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body), "Body should still be sent after signing")
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.GetInterceptorManager().AddInterceptor(axios.SigV4Interceptor("AKIDEXAMPLE", "secret", "us-east-1", "s3"))
+	var completed int
+	_, err := client.Put(context.TODO(), server.URL+"/bucket/key", []byte("payload"), axios.Config{
+		Headers: http.Header{"X-Amz-Target": []string{"Bucket.PutObject"}},
+		OnUploadProgress: func(written, total int64) {
+			if written == total {
+				completed++
+			}
+		},
+	})
+	assert.NoError(t, err, "Signed request should succeed")
+	assert.Equal(t, 1, completed, "Hashing the body for the signature should not be reported as an upload")
+}
+
+// TestRequestIDInterceptorRetries verifies which request IDs are kept when a request is retried.
+func TestRequestIDInterceptorRetries(t *testing.T) {
+	// Mock server that records each attempt's ID and fails every first attempt
+	var mu sync.Mutex
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		ids = append(ids, r.Header.Get("X-Request-ID"))
+		if len(ids)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{
+		Timeout: 10,
+		Retry:   &axios.RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond},
+	}, nil)
+	client.GetInterceptorManager().AddInterceptor(axios.RequestIDInterceptor("", nil))
+
+	// IDs from Config.Headers and WithRequestID are sent on every attempt
+	_, err := client.Get(context.TODO(), server.URL, axios.Config{
+		Headers: http.Header{"X-Request-ID": []string{"header-7"}},
+	})
+	assert.NoError(t, err, "Retried request should succeed")
+	_, err = client.Get(axios.WithRequestID(context.TODO(), "incoming-42"), server.URL)
+	assert.NoError(t, err, "Retried request should succeed")
+
+	// Generated IDs are new for every attempt
+	_, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Retried request should succeed")
+
+	assert.Equal(t, []string{"header-7", "header-7", "incoming-42", "incoming-42"}, ids[:4], "Caller's IDs should be kept across retries")
+	assert.NotEqual(t, ids[4], ids[5], "Each attempt should get its own generated ID")
+}
+
+// TestHMACSignInterceptor verifies that bodies are signed and that servers can verify the signature.
+func TestHMACSignInterceptor(t *testing.T) {
+	secret := []byte("webhook-secret")
+
+	// Mock server that verifies the signature of bodies up to 64 bytes and echoes the body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := axios.VerifyHMACRequest(r, secret, "X-Signature", sha256.New, 64)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	id := client.GetInterceptorManager().AddInterceptor(axios.HMACSignInterceptor(secret, "X-Signature", sha256.New))
+
+	resp, err := client.Post(context.TODO(), server.URL, []byte(`{"event": "ping"}`))
+	assert.NoError(t, err, "Signed request should be accepted")
+	assert.Equal(t, `{"event": "ping"}`, resp.String(), "Body should still be readable after verification")
+
+	// Bodies over the verification limit are refused before being buffered whole
+	_, err = client.Post(context.TODO(), server.URL, bytes.Repeat([]byte("x"), 65))
+	reqErr, isStatus := axios.IsStatusError(err)
+	if assert.True(t, isStatus, "Oversized body should be rejected") {
+		assert.Equal(t, http.StatusRequestEntityTooLarge, reqErr.StatusCode, "Verification should stop at the body limit")
+	}
+
+	// Streamed bodies cannot be signed
+	_, err = client.Post(context.TODO(), server.URL, nil, axios.Config{BodyReader: io.MultiReader(strings.NewReader("x"))})
+	assert.ErrorContains(t, err, "cannot be signed", "Streamed bodies should be rejected")
+
+	// A wrong secret fails verification
+	client.GetInterceptorManager().RemoveInterceptor(id)
+	client.GetInterceptorManager().AddInterceptor(axios.HMACSignInterceptor([]byte("wrong"), "X-Signature", sha256.New))
+	_, err = client.Post(context.TODO(), server.URL, []byte(`{"event": "ping"}`))
+	reqErr, isStatus = axios.IsStatusError(err)
+	if assert.True(t, isStatus, "Wrongly signed request should be rejected") {
+		assert.Equal(t, http.StatusUnauthorized, reqErr.StatusCode, "Server should reject the signature")
+	}
+}
+
+// TestRequestIDInterceptor verifies that requests are tagged with a generated or caller-chosen ID that responses can read back.
+func TestRequestIDInterceptor(t *testing.T) {
+	// Mock server that echoes the request ID headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s|%s", strings.Join(r.Header.Values("X-Request-ID"), ","), r.Header.Get("X-Trace"))
+	}))
+	defer server.Close()
+
+	// A UUID is generated under X-Request-ID by default
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.GetInterceptorManager().AddInterceptor(axios.RequestIDInterceptor("", nil))
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	id, ok := axios.RequestID(resp.Request)
+	assert.True(t, ok, "Response should carry the request ID")
+	assert.Len(t, id, 36, "Default ID should be a UUID")
+	assert.Equal(t, id+"|", string(resp.Body), "Server should receive the generated ID")
+
+	first := id
+	resp, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	id, _ = axios.RequestID(resp.Request)
+	assert.NotEqual(t, first, id, "Each request should get its own ID")
+
+	// A caller-chosen ID is kept
+	resp, err = client.Get(axios.WithRequestID(context.TODO(), "incoming-42"), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "incoming-42|", string(resp.Body), "Caller's ID should be sent")
+
+	// An ID in the config's headers is kept and sent once
+	resp, err = client.Get(context.TODO(), server.URL, axios.Config{
+		Headers: http.Header{"X-Request-ID": []string{"header-7"}},
+	})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "header-7|", string(resp.Body), "Config header ID should be sent")
+	assert.Equal(t, []string{"header-7"}, resp.Request.Header.Values("X-Request-ID"), "Only one ID should be sent")
+	id, _ = axios.RequestID(resp.Request)
+	assert.Equal(t, "header-7", id, "Config header ID should be readable from the response")
+
+	// Custom header and generator; an ID set by an earlier interceptor is not overwritten
+	client = axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.GetInterceptorManager().AddInterceptor(axios.Interceptor{
+		Request: func(req *http.Request) (*http.Request, error) {
+			if req.URL.Path == "/preset" {
+				req.Header.Set("X-Trace", "preset")
+			}
+			return req, nil
+		},
+	})
+	client.GetInterceptorManager().AddInterceptor(axios.RequestIDInterceptor("X-Trace", func() string { return "generated" }))
+	resp, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "|generated", string(resp.Body), "Custom header and generator should be used")
+	resp, err = client.Get(context.TODO(), server.URL+"/preset")
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "|preset", string(resp.Body), "Existing ID should not be overwritten")
+	id, _ = axios.RequestID(resp.Request)
+	assert.Equal(t, "preset", id, "Existing ID should be readable from the response")
+}
@@ -0,0 +1,408 @@
+package axios_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/stretchr/testify/assert"
+)
+
+// tickLimiter allows one request per interval, like a token bucket with a burst of one
+type tickLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *tickLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// TestClientRateLimiter verifies that concurrent requests are throttled and waiting respects cancellation.
+func TestClientRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetRateLimiter(&tickLimiter{interval: 50 * time.Millisecond})
+
+	// Five concurrent requests need at least four intervals
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Get(context.TODO(), server.URL)
+			assert.NoError(t, err, "Request should succeed")
+		}()
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond, "Requests should be throttled")
+
+	// A canceled context stops waiting for the limiter
+	client.SetRateLimiter(&tickLimiter{interval: time.Hour, next: time.Now().Add(time.Hour)})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Get(ctx, server.URL)
+	assert.True(t, axios.IsTimeout(err), "Waiting for the limiter should respect the context")
+}
+
+// TestClientMaxConcurrency verifies that in-flight requests are bounded and streams hold their slot until closed.
+func TestClientMaxConcurrency(t *testing.T) {
+	// Mock server that tracks the peak number of concurrent requests
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetMaxConcurrency(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Get(context.TODO(), server.URL)
+			assert.NoError(t, err, "Request should succeed")
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, peak, 2, "No more than two requests should be in flight")
+
+	// An open stream holds the only slot until it is closed
+	client.SetMaxConcurrency(1)
+	stream, err := client.RequestStream(context.TODO(), axios.Config{Method: "GET", URL: server.URL})
+	assert.NoError(t, err, "Streaming request should succeed")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Get(ctx, server.URL)
+	assert.True(t, axios.IsTimeout(err), "Waiting for a slot should respect the context")
+
+	stream.RawBody.Close()
+	stream.RawBody.Close() // Closing twice must not free the slot twice
+	_, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Slot should be free after the stream is closed")
+
+	// A download releases its slot when done
+	err = client.Download(context.TODO(), axios.Config{Method: "GET", URL: server.URL}, t.TempDir()+"/file")
+	assert.NoError(t, err, "Download should succeed")
+	_, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Slot should be free after the download")
+}
+
+// TestClientCircuitBreaker verifies that a failing host is short-circuited and probed again after the open duration.
+func TestClientCircuitBreaker(t *testing.T) {
+	// Mock server that fails until told to recover
+	var hits, healthy atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if healthy.Load() == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetCircuitBreaker(&axios.CircuitBreakerConfig{
+		FailureThreshold: 3,
+		OpenDuration:     50 * time.Millisecond,
+	})
+
+	// Consecutive failures open the circuit, after which the network is not touched
+	for i := 0; i < 3; i++ {
+		_, err := client.Get(context.TODO(), server.URL)
+		_, isStatus := axios.IsStatusError(err)
+		assert.True(t, isStatus, "Failures should reach the server")
+	}
+	_, err := client.Get(context.TODO(), server.URL, axios.Config{Retry: &axios.RetryConfig{MaxRetries: 3}})
+	assert.True(t, errors.Is(err, axios.ErrCircuitOpen), "Open circuit should fail fast")
+	assert.Equal(t, int32(3), hits.Load(), "Open circuit should not send requests or retries")
+
+	// A failed probe reopens the circuit
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.Get(context.TODO(), server.URL)
+	_, isStatus := axios.IsStatusError(err)
+	assert.True(t, isStatus, "Probe should reach the server")
+	_, err = client.Get(context.TODO(), server.URL)
+	assert.True(t, errors.Is(err, axios.ErrCircuitOpen), "Failed probe should reopen the circuit")
+
+	// A successful probe closes it again
+	healthy.Store(1)
+	time.Sleep(60 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		_, err = client.Get(context.TODO(), server.URL)
+		assert.NoError(t, err, "Circuit should be closed after a successful probe")
+	}
+	assert.Equal(t, int32(7), hits.Load(), "Every request after recovery should be sent")
+}
+
+// TestClientDedupe verifies that identical concurrent GETs share one request while each caller gets its own response.
+func TestClientDedupe(t *testing.T) {
+	// Mock server that holds requests until released
+	var hits atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, Dedupe: true}, nil)
+
+	const numRequests = 10
+	responses := make([]*axios.Response, numRequests)
+	results := make([]map[string]int, numRequests)
+	var wg sync.WaitGroup
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(context.TODO(), server.URL, axios.Config{Result: &results[i]})
+			assert.NoError(t, err, "Deduplicated request should succeed")
+			responses[i] = resp
+		}()
+	}
+	time.Sleep(50 * time.Millisecond) // Let every request join the in-flight one
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), hits.Load(), "Identical requests should share one round trip")
+	for i := 0; i < numRequests; i++ {
+		assert.Equal(t, map[string]int{"id": 1}, results[i], "Every caller's Result should be decoded")
+	}
+	responses[0].Body[0] = 'x'
+	assert.Equal(t, `{"id": 1}`, responses[1].String(), "Callers should get independent copies")
+
+	// Requests with different credentials are not shared
+	var authHits atomic.Int32
+	authRelease := make(chan struct{})
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHits.Add(1)
+		<-authRelease
+	}))
+	defer authServer.Close()
+
+	for _, token := range []string{"a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Get(context.TODO(), authServer.URL, axios.Config{BearerToken: token})
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(authRelease)
+	wg.Wait()
+	assert.Equal(t, int32(2), authHits.Load(), "Different credentials should not be deduplicated")
+
+	// Requests writing to their own ResponseWriter are not shared, so each writer gets the body
+	var fileHits atomic.Int32
+	fileRelease := make(chan struct{})
+	fileServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fileHits.Add(1)
+		<-fileRelease
+		w.Write([]byte("file contents"))
+	}))
+	defer fileServer.Close()
+
+	writers := []*bytes.Buffer{{}, {}}
+	for _, writer := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Get(context.TODO(), fileServer.URL, axios.Config{ResponseWriter: writer})
+			assert.NoError(t, err, "Request with a ResponseWriter should succeed")
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(fileRelease)
+	wg.Wait()
+	assert.Equal(t, int32(2), fileHits.Load(), "Requests with a ResponseWriter should not be deduplicated")
+	for _, writer := range writers {
+		assert.Equal(t, "file contents", writer.String(), "Every ResponseWriter should receive the body")
+	}
+}
+
+// TestClientDedupeIsolation verifies that requests differing in ways the key cannot see are not shared, and that each caller's context only affects that caller.
+func TestClientDedupeIsolation(t *testing.T) {
+	// Mock server that holds requests until released and reports cancelled ones
+	var hits atomic.Int32
+	release := make(chan struct{})
+	cancelled := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		select {
+		case <-release:
+			w.Write([]byte("shared"))
+		case <-r.Context().Done():
+			cancelled <- struct{}{}
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, Dedupe: true}, nil)
+
+	// Requests differing in Values or NoStatusError, or rewritten by interceptors, are sent separately
+	var wg sync.WaitGroup
+	configs := []axios.Config{
+		{Values: map[string]interface{}{"tenant": "a"}},
+		{Values: map[string]interface{}{"tenant": "b"}},
+		{NoStatusError: true},
+		{},
+	}
+	for _, config := range configs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Get(context.TODO(), server.URL, config)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(4), hits.Load(), "Requests the key cannot tell apart should not be shared")
+
+	intercepted := client.Clone()
+	intercepted.GetInterceptorManager().AddRequestInterceptor(func(req *http.Request) (*http.Request, error) {
+		return req, nil
+	})
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			intercepted.Get(context.TODO(), server.URL)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(6), hits.Load(), "Requests through request interceptors should not be shared")
+	close(release)
+	wg.Wait()
+
+	// Cancelling the caller that started a shared request does not fail the others
+	hits.Store(0)
+	release = make(chan struct{})
+	first, cancelFirst := context.WithCancel(context.Background())
+	errs := make([]error, 2)
+	for i, ctx := range []context.Context{first, context.Background()} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = client.Get(ctx, server.URL+"/shared")
+		}()
+		time.Sleep(20 * time.Millisecond) // The first caller starts the request
+	}
+	cancelFirst()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.ErrorIs(t, errs[0], context.Canceled, "Cancelled caller should get its own error")
+	assert.NoError(t, errs[1], "Other callers should still get the response")
+	assert.Equal(t, int32(1), hits.Load(), "Callers should share one request")
+
+	// Once every caller has given up, the shared request is cancelled
+	release = make(chan struct{})
+	defer close(release)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	_, err := client.Get(ctx, server.URL+"/abandoned")
+	assert.ErrorIs(t, err, context.Canceled, "Cancelled caller should get its own error")
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Error("Abandoned shared request should be cancelled")
+	}
+}
+
+// TestClientDo verifies that batched requests run with bounded concurrency and report results in order.
+func TestClientDo(t *testing.T) {
+	// Mock server that echoes the path, fails /fail and tracks peak concurrency
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, BaseURL: server.URL}, nil)
+
+	paths := []string{"/a", "/b", "/fail", "/c", "/d", "/e"}
+	configs := make([]axios.Config, len(paths))
+	for i, path := range paths {
+		configs[i] = axios.Config{Method: "GET", URL: path}
+	}
+
+	responses, errs := client.Do(context.TODO(), configs, 2)
+	for i, path := range paths {
+		if path == "/fail" {
+			assert.Error(t, errs[i], "Failed request should report its error")
+			assert.Nil(t, responses[i], "Failed request should have no response")
+			continue
+		}
+		assert.NoError(t, errs[i], "Request %s should succeed", path)
+		assert.Equal(t, path, responses[i].String(), "Results should be in input order")
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(2), "No more than two requests should be in flight")
+
+	// A canceled context skips requests that have not started
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, errs = client.Do(ctx, configs, 1)
+	for i := range configs {
+		assert.True(t, axios.IsCanceled(errs[i]), "Every request should report the cancellation")
+	}
+}
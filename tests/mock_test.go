@@ -0,0 +1,73 @@
+package axios_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMockTransport verifies canned responses by method and URL pattern and the captured requests.
+func TestMockTransport(t *testing.T) {
+	client := axios.NewClient(axios.Config{BaseURL: "https://api.example.com"}, nil)
+	mock := client.Mock()
+	mock.On(http.MethodGet, "/users/*", axios.MockJSON(http.StatusOK, map[string]string{"name": "Ada"}))
+	mock.On(http.MethodPost, "https://api.example.com/users", axios.MockString(http.StatusCreated, "created"))
+	mock.On("", "/down", axios.MockError(errors.New("connection refused")))
+
+	var user struct{ Name string }
+	resp, err := client.Get(context.Background(), "/users/42?fields=name", axios.Config{Result: &user})
+	assert.NoError(t, err, "Mocked GET should succeed")
+	assert.Equal(t, "Ada", user.Name, "Mocked JSON should be decoded")
+	assert.Equal(t, "application/json", resp.Headers.Get("Content-Type"), "Mocked response should carry its content type")
+
+	resp, err = client.Post(context.Background(), "/users", []byte(`{"name":"Grace"}`))
+	assert.NoError(t, err, "Mocked POST should succeed")
+	assert.Equal(t, http.StatusCreated, resp.StatusCode, "Mocked status should be returned")
+	assert.Equal(t, "created", string(resp.Body), "Mocked body should be returned")
+
+	_, err = client.Get(context.Background(), "/down")
+	assert.ErrorContains(t, err, "connection refused", "Mocked error should be returned")
+
+	_, err = client.Delete(context.Background(), "/users/42")
+	assert.ErrorIs(t, err, axios.ErrNoMockResponse, "Unmatched request should fail")
+
+	requests := mock.Requests()
+	assert.Len(t, requests, 4, "Every request should be captured")
+	assert.Equal(t, "https://api.example.com/users/42?fields=name", requests[0].URL, "Captured URL should include the query")
+	assert.Equal(t, `{"name":"Grace"}`, string(requests[1].Body), "Captured request should carry its body")
+	assert.Equal(t, 2, mock.Calls("", "/users/*"), "Calls should count matching requests")
+	assert.Equal(t, 1, mock.Calls(http.MethodPost, "/users"), "Calls should filter by method")
+
+	mock.Reset()
+	assert.Empty(t, mock.Requests(), "Reset should clear captured requests")
+}
+
+// TestMockTransportLeavesRequestAlone verifies that the responder gets a copy of the request,
+// so the caller's request keeps its own body.
+func TestMockTransportLeavesRequestAlone(t *testing.T) {
+	mock := axios.NewMockTransport()
+	mock.On(http.MethodPost, "/echo", func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err, "Responder should read the body")
+		assert.Equal(t, "payload", string(body), "Responder should see the request body")
+		req.Header.Set("X-Responder", "changed")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: http.Header{}}, nil
+	})
+
+	body := io.NopCloser(strings.NewReader("payload"))
+	req, err := http.NewRequest(http.MethodPost, "https://api.example.com/echo", body)
+	assert.NoError(t, err, "Building the request should succeed")
+
+	resp, err := mock.RoundTrip(req)
+	assert.NoError(t, err, "Mocked round trip should succeed")
+	assert.Same(t, req, resp.Request, "Response should refer to the caller's request")
+	assert.Equal(t, body, req.Body, "Caller's request body should not be replaced")
+	assert.Empty(t, req.Header.Get("X-Responder"), "Caller's headers should not be modified")
+	assert.Equal(t, "payload", string(mock.Requests()[0].Body), "Captured request should carry its body")
+}
@@ -0,0 +1,407 @@
+package axios_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResponseParseXML verifies XML decoding and the error for an empty body.
+func TestResponseParseXML(t *testing.T) {
+	type post struct {
+		ID    int    `xml:"id"`
+		Title string `xml:"title"`
+	}
+
+	resp := &axios.Response{Body: []byte(`<post><id>1</id><title>foo</title></post>`)}
+	var parsed post
+	assert.NoError(t, resp.ParseXML(&parsed), "XML parsing should not return an error")
+	assert.Equal(t, post{ID: 1, Title: "foo"}, parsed, "Parsed XML should match")
+
+	empty := &axios.Response{}
+	assert.ErrorIs(t, empty.ParseXML(&parsed), axios.ErrEmptyBody, "Empty body should return ErrEmptyBody")
+
+	invalid := &axios.Response{Body: []byte(`<post>`)}
+	assert.Error(t, invalid.ParseXML(&parsed), "Malformed XML should return an error")
+}
+
+// TestResponseDecode verifies that the body is decoded according to its Content-Type.
+func TestResponseDecode(t *testing.T) {
+	type post struct {
+		ID    int    `json:"id" xml:"id"`
+		Title string `json:"title" xml:"title"`
+	}
+	withType := func(contentType, body string) *axios.Response {
+		return &axios.Response{Body: []byte(body), Headers: http.Header{"Content-Type": {contentType}}}
+	}
+
+	// JSON, including structured suffixes
+	var parsed post
+	assert.NoError(t, withType("application/json; charset=utf-8", `{"id":1,"title":"foo"}`).Decode(&parsed), "JSON should be decoded")
+	assert.Equal(t, post{ID: 1, Title: "foo"}, parsed, "Decoded JSON should match")
+	parsed = post{}
+	assert.NoError(t, withType("application/problem+json", `{"id":2}`).Decode(&parsed), "+json types should be decoded as JSON")
+	assert.Equal(t, 2, parsed.ID, "Decoded +json body should match")
+
+	// XML
+	parsed = post{}
+	assert.NoError(t, withType("text/xml", `<post><id>3</id><title>bar</title></post>`).Decode(&parsed), "XML should be decoded")
+	assert.Equal(t, post{ID: 3, Title: "bar"}, parsed, "Decoded XML should match")
+
+	// URL-encoded forms
+	var form url.Values
+	assert.NoError(t, withType("application/x-www-form-urlencoded", "a=1&a=2&b=3").Decode(&form), "Form should be decoded")
+	assert.Equal(t, url.Values{"a": {"1", "2"}, "b": {"3"}}, form, "Decoded form should keep every value")
+	var fields map[string]string
+	assert.NoError(t, withType("application/x-www-form-urlencoded", "a=1&a=2&b=3").Decode(&fields), "Form should be decoded into a map")
+	assert.Equal(t, map[string]string{"a": "1", "b": "3"}, fields, "Decoded map should keep the first values")
+	assert.Error(t, withType("application/x-www-form-urlencoded", "a=1").Decode(&parsed), "Form should not decode into a struct")
+
+	// Unsupported or missing types
+	assert.ErrorIs(t, withType("application/octet-stream", "a,b").Decode(&parsed), axios.ErrUnsupportedContentType, "Unknown types should be rejected")
+	assert.ErrorIs(t, (&axios.Response{Body: []byte("{}")}).Decode(&parsed), axios.ErrUnsupportedContentType, "Missing Content-Type should be rejected")
+}
+
+// TestResponseParseJSONStrict verifies strict decoding and json.Number decoding.
+func TestResponseParseJSONStrict(t *testing.T) {
+	type user struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	resp := &axios.Response{Body: []byte(`{"id": 9007199254740993, "name": "alice", "role": "admin"}` + "\n")}
+
+	// Unknown fields are ignored by default and rejected in strict mode
+	var parsed user
+	assert.NoError(t, resp.ParseJSONWith(&parsed, nil), "Lenient parsing should ignore unknown fields")
+	assert.Equal(t, user{ID: 9007199254740993, Name: "alice"}, parsed, "Known fields should be decoded")
+	err := resp.ParseJSONStrict(&parsed)
+	assert.ErrorContains(t, err, `unknown field "role"`, "Strict parsing should reject unknown fields")
+
+	// Numbers can keep their precision in untyped values
+	var loose map[string]interface{}
+	assert.NoError(t, resp.ParseJSONWith(&loose, &axios.JSONOptions{UseNumber: true}), "Parsing with UseNumber should succeed")
+	assert.Equal(t, json.Number("9007199254740993"), loose["id"], "Large integers should not be rounded")
+
+	// Empty bodies and trailing data are rejected
+	assert.ErrorIs(t, (&axios.Response{}).ParseJSONStrict(&parsed), axios.ErrEmptyBody, "Empty body should return ErrEmptyBody")
+	trailing := &axios.Response{Body: []byte(`{"id": 1} {"id": 2}`)}
+	assert.ErrorContains(t, trailing.ParseJSONStrict(&parsed), "unexpected data", "Trailing data should be rejected")
+}
+
+// TestResponseAccessors verifies the String, Bytes and ContentType helpers.
+func TestResponseAccessors(t *testing.T) {
+	resp := &axios.Response{
+		Body:    []byte(`{"key": "value"}`),
+		Headers: http.Header{"Content-Type": []string{"application/json"}},
+	}
+
+	assert.Equal(t, `{"key": "value"}`, resp.String(), "String should return the body")
+	assert.Equal(t, []byte(`{"key": "value"}`), resp.Bytes(), "Bytes should return the body")
+	assert.Equal(t, "application/json", resp.ContentType(), "ContentType should read the header")
+}
+
+// TestResponseStatusClasses verifies the status class helpers for each class of status code.
+func TestResponseStatusClasses(t *testing.T) {
+	for _, tc := range []struct {
+		status                                          int
+		success, redirect, isError, client, serverError bool
+	}{
+		{http.StatusOK, true, false, false, false, false},
+		{http.StatusNoContent, true, false, false, false, false},
+		{http.StatusMovedPermanently, false, true, false, false, false},
+		{http.StatusNotFound, false, false, true, true, false},
+		{http.StatusTooManyRequests, false, false, true, true, false},
+		{http.StatusBadGateway, false, false, true, false, true},
+	} {
+		resp := &axios.Response{StatusCode: tc.status}
+		assert.Equal(t, tc.success, resp.IsSuccess(), "IsSuccess for %d", tc.status)
+		assert.Equal(t, tc.redirect, resp.IsRedirect(), "IsRedirect for %d", tc.status)
+		assert.Equal(t, tc.isError, resp.IsError(), "IsError for %d", tc.status)
+		assert.Equal(t, tc.client, resp.IsClientError(), "IsClientError for %d", tc.status)
+		assert.Equal(t, tc.serverError, resp.IsServerError(), "IsServerError for %d", tc.status)
+	}
+}
+
+// TestResponseDuration verifies that requests record when they started and how long they took.
+func TestResponseDuration(t *testing.T) {
+	// Mock server that responds slowly
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	before := time.Now()
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+
+	assert.False(t, resp.StartedAt.Before(before), "StartedAt should be set when the request is sent")
+	assert.GreaterOrEqual(t, resp.Duration, 20*time.Millisecond, "Duration should cover the server's response time")
+}
+
+// TestResponseTimings verifies that traced requests break down their connection phases.
+func TestResponseTimings(t *testing.T) {
+	// Mock TLS server that is slow to produce its first byte
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := axios.NewClientWithHTTPClient(axios.Config{}, server.Client())
+
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Untraced request should succeed")
+	assert.Nil(t, resp.Timings, "Timings should only be recorded when tracing")
+
+	traced := axios.Config{Trace: true}
+	resp, err = client.Get(context.TODO(), server.URL, traced)
+	assert.NoError(t, err, "Traced request should succeed")
+	if assert.NotNil(t, resp.Timings, "Traced request should record timings") {
+		assert.True(t, resp.Timings.ConnReused, "Second request should reuse the connection")
+		assert.GreaterOrEqual(t, resp.Timings.TimeToFirstByte, 20*time.Millisecond, "TTFB should include server time")
+	}
+
+	// A fresh connection records connect and TLS handshake times
+	server.Client().CloseIdleConnections()
+	resp, err = client.Get(context.TODO(), server.URL, traced)
+	assert.NoError(t, err, "Traced request should succeed")
+	if assert.NotNil(t, resp.Timings, "Traced request should record timings") {
+		assert.False(t, resp.Timings.ConnReused, "Connection should be new")
+		assert.Positive(t, resp.Timings.Connect, "Connect time should be recorded")
+		assert.Positive(t, resp.Timings.TLSHandshake, "TLS handshake time should be recorded")
+	}
+}
+
+// TestResponseEachJSONLine verifies NDJSON decoding from buffered and streamed bodies.
+func TestResponseEachJSONLine(t *testing.T) {
+	type event struct {
+		ID int `json:"id"`
+	}
+	decode := func(resp *axios.Response) ([]int, error) {
+		var ids []int
+		err := resp.EachJSONLine(func(raw []byte) error {
+			var e event
+			if err := json.Unmarshal(raw, &e); err != nil {
+				return err
+			}
+			ids = append(ids, e.ID)
+			return nil
+		})
+		return ids, err
+	}
+
+	// Blank lines and CRLF endings are tolerated, and the last line needs no newline
+	buffered := &axios.Response{Body: []byte("{\"id\":1}\r\n\n{\"id\":2}\n{\"id\":3}")}
+	ids, err := decode(buffered)
+	assert.NoError(t, err, "Decoding should succeed")
+	assert.Equal(t, []int{1, 2, 3}, ids, "Every line should be decoded")
+
+	// A streamed body is read incrementally
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, "{\"id\":%d}\n", i)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	stream, err := client.RequestStream(context.TODO(), axios.Config{Method: "GET", URL: server.URL})
+	assert.NoError(t, err, "Streaming request should succeed")
+	defer stream.RawBody.Close()
+	ids, err = decode(stream)
+	assert.NoError(t, err, "Decoding should succeed")
+	assert.Equal(t, []int{1, 2, 3}, ids, "Every streamed line should be decoded")
+
+	// The callback's error stops iteration
+	ids, err = decode(&axios.Response{Body: []byte("{\"id\":1}\nnot json\n{\"id\":3}\n")})
+	assert.Error(t, err, "Malformed line should return an error")
+	assert.Equal(t, []int{1}, ids, "Lines after the error should not be decoded")
+}
+
+// TestResponseMsgpack verifies that MessagePack bodies are encoded and decoded with the configured codec.
+func TestResponseMsgpack(t *testing.T) {
+	type point struct{ X, Y int }
+
+	// Stand-in codec; a real one wraps a MessagePack library's Marshal and Unmarshal
+	codec := axios.CodecFuncs{MarshalFunc: json.Marshal, UnmarshalFunc: json.Unmarshal}
+
+	// Mock server that echoes the body back as MessagePack
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, MsgpackCodec: codec}, nil)
+	resp, err := client.Post(context.TODO(), server.URL, nil, axios.Config{MsgpackBody: point{X: 1, Y: 2}})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "application/msgpack", resp.ContentType(), "Content-Type should be set for MessagePack bodies")
+
+	var decoded point
+	assert.NoError(t, resp.ParseMsgpack(&decoded), "MessagePack parsing should not return an error")
+	assert.Equal(t, point{X: 1, Y: 2}, decoded, "Decoded body should round-trip")
+
+	// Other content types are rejected
+	resp.Headers.Set("Content-Type", "application/json")
+	assert.ErrorContains(t, resp.ParseMsgpack(&decoded), "unexpected Content-Type", "Non-MessagePack body should be rejected")
+
+	// Without a codec nothing is encoded or decoded
+	bare := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	_, err = bare.Post(context.TODO(), server.URL, nil, axios.Config{MsgpackBody: point{}})
+	assert.ErrorIs(t, err, axios.ErrNoCodec, "Encoding without a codec should fail")
+	assert.ErrorIs(t, (&axios.Response{Body: []byte{0x80}}).ParseMsgpack(&decoded), axios.ErrNoCodec, "Decoding without a codec should fail")
+}
+
+// TestResponseProto verifies that ProtoBody and ParseProto go through the configured codec.
+func TestResponseProto(t *testing.T) {
+	type message struct{ Value string }
+
+	// Stand-in codec; protoaxios.Codec wraps the protobuf runtime's Marshal and Unmarshal
+	codec := axios.CodecFuncs{MarshalFunc: json.Marshal, UnmarshalFunc: json.Unmarshal}
+
+	// Mock server that echoes the body back along with its Content-Type
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10, ProtoCodec: codec}, nil)
+	resp, err := client.Post(context.TODO(), server.URL, nil, axios.Config{ProtoBody: message{Value: "hello"}})
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "application/x-protobuf", resp.ContentType(), "Content-Type should be set for protobuf bodies")
+
+	var decoded message
+	assert.NoError(t, resp.ParseProto(&decoded), "Protobuf parsing should not return an error")
+	assert.Equal(t, message{Value: "hello"}, decoded, "Decoded body should round-trip")
+
+	// Without a codec nothing is encoded or decoded, and ProtoBody conflicts with other bodies
+	bare := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	_, err = bare.Post(context.TODO(), server.URL, nil, axios.Config{ProtoBody: message{}})
+	assert.ErrorIs(t, err, axios.ErrNoCodec, "Encoding without a codec should fail")
+	assert.ErrorIs(t, (&axios.Response{}).ParseProto(&decoded), axios.ErrNoCodec, "Decoding without a codec should fail")
+	_, err = client.Post(context.TODO(), server.URL, []byte("raw"), axios.Config{ProtoBody: message{}})
+	assert.ErrorIs(t, err, axios.ErrConflictingBody, "ProtoBody should conflict with Body")
+}
+
+// TestResponseParseCSV verifies CSV parsing into rows and into tagged structs.
+func TestResponseParseCSV(t *testing.T) {
+	resp := &axios.Response{Body: []byte("name;age;active\nalice;30;true\n\"bob; jr\";;false\n")}
+
+	// Rows with a custom delimiter, with and without the header
+	rows, err := resp.ParseCSV(&axios.CSVOptions{Comma: ';'})
+	assert.NoError(t, err, "CSV parsing should not return an error")
+	assert.Equal(t, [][]string{{"name", "age", "active"}, {"alice", "30", "true"}, {"bob; jr", "", "false"}}, rows, "Rows should match")
+	rows, err = resp.ParseCSV(&axios.CSVOptions{Comma: ';', SkipHeader: true})
+	assert.NoError(t, err, "CSV parsing should not return an error")
+	assert.Len(t, rows, 2, "Header row should be skipped")
+
+	// Structs matched by tag or field name
+	type user struct {
+		Name   string `csv:"name"`
+		Age    int
+		Active bool `csv:"active"`
+		Note   string
+	}
+	var users []user
+	assert.NoError(t, resp.ParseCSVInto(&users, &axios.CSVOptions{Comma: ';'}), "Decoding into structs should succeed")
+	assert.Equal(t, []user{{Name: "alice", Age: 30, Active: true}, {Name: "bob; jr"}}, users, "Structs should be filled from their columns")
+
+	var pointers []*user
+	assert.NoError(t, resp.ParseCSVInto(&pointers, &axios.CSVOptions{Comma: ';'}), "Decoding into struct pointers should succeed")
+	assert.Equal(t, "alice", pointers[0].Name, "Struct pointers should be filled")
+
+	// Fields promoted from embedded structs are filled, except through nil embedded pointers
+	type Profile struct {
+		Age int
+	}
+	type Status struct {
+		Active bool `csv:"active"`
+	}
+	type embedded struct {
+		Name string `csv:"name"`
+		Profile
+		*Status
+	}
+	var embeds []embedded
+	assert.NoError(t, resp.ParseCSVInto(&embeds, &axios.CSVOptions{Comma: ';'}), "Decoding into embedding structs should not panic")
+	assert.Equal(t, embedded{Name: "alice", Profile: Profile{Age: 30}}, embeds[0], "Only fields reachable without a pointer should be filled")
+
+	// Invalid values and targets are reported
+	invalid := &axios.Response{Body: []byte("name,age\nalice,thirty\n")}
+	assert.ErrorContains(t, invalid.ParseCSVInto(&users, nil), `row 2, column "age"`, "Invalid values should name their cell")
+	assert.Error(t, resp.ParseCSVInto(users, nil), "Non-pointer targets should be rejected")
+	assert.ErrorIs(t, (&axios.Response{}).ParseCSVInto(&users, nil), axios.ErrEmptyBody, "Empty body should return ErrEmptyBody")
+}
+
+// TestResponseJSONStream verifies that JSON values and array elements are decoded as they arrive.
+func TestResponseJSONStream(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	// Mock server that only sends the next element once the previous one was decoded
+	received := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/object" {
+			w.Write([]byte(`{"id": 7}`))
+			return
+		}
+		w.Write([]byte("["))
+		for i := 1; i <= 3; i++ {
+			if i > 1 {
+				w.Write([]byte(","))
+			}
+			fmt.Fprintf(w, `{"id":%d}`, i)
+			w.(http.Flusher).Flush()
+			select {
+			case <-received:
+			case <-time.After(2 * time.Second):
+				return // The client did not decode incrementally
+			}
+		}
+		w.Write([]byte("]"))
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	stream, err := client.RequestStream(context.TODO(), axios.Config{URL: server.URL + "/array"})
+	assert.NoError(t, err, "Streaming request should succeed")
+	defer stream.RawBody.Close()
+
+	var ids []int
+	for element, err := range axios.JSONArrayElements[item](stream) {
+		assert.NoError(t, err, "Element should be decoded")
+		ids = append(ids, element.ID)
+		received <- struct{}{}
+	}
+	assert.Equal(t, []int{1, 2, 3}, ids, "Every element should be decoded in order")
+
+	// A single streamed value
+	stream, err = client.RequestStream(context.TODO(), axios.Config{URL: server.URL + "/object"})
+	assert.NoError(t, err, "Streaming request should succeed")
+	defer stream.RawBody.Close()
+	var single item
+	assert.NoError(t, stream.DecodeJSONStream(&single), "Streamed value should be decoded")
+	assert.Equal(t, 7, single.ID, "Decoded value should match")
+
+	// Buffered bodies that are not arrays are reported
+	for _, err := range axios.JSONArrayElements[item](&axios.Response{Body: []byte(`{"id": 1}`)}) {
+		assert.ErrorContains(t, err, "not a JSON array", "Non-array body should be rejected")
+	}
+	assert.ErrorIs(t, (&axios.Response{}).DecodeJSONStream(&single), axios.ErrEmptyBody, "Empty body should return ErrEmptyBody")
+}
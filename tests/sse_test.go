@@ -0,0 +1,103 @@
+package axios_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClientStreamEvents verifies SSE parsing and reconnection with Last-Event-ID.
+func TestClientStreamEvents(t *testing.T) {
+	// Mock server that sends two events, drops the connection, resumes after the last ID and then stops
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"), "Accept header should request an event stream")
+		switch connections.Add(1) {
+		case 1:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, ": comment\nretry: 10\n\n")
+			fmt.Fprint(w, "id: 1\ndata: first\ndata: line\n\n")
+			fmt.Fprint(w, "id: 2\nevent: update\ndata: second\r\n\r\n")
+			fmt.Fprint(w, "data: incomplete")
+		case 2:
+			assert.Equal(t, "2", r.Header.Get("Last-Event-ID"), "Reconnect should resume after the last event")
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 3\ndata: third\n\n")
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{}, nil)
+	events, err := client.StreamEvents(context.TODO(), axios.Config{Method: "GET", URL: server.URL},
+		&axios.EventStreamOptions{Reconnect: true, ReconnectDelay: time.Second})
+	assert.NoError(t, err, "Opening the stream should succeed")
+
+	var received []axios.Event
+	for event := range events {
+		received = append(received, event)
+	}
+	assert.Equal(t, []axios.Event{
+		{ID: "1", Event: "message", Data: "first\nline"},
+		{ID: "2", Event: "update", Data: "second"},
+		{ID: "3", Event: "message", Data: "third"},
+	}, received, "Events should be parsed across the reconnect")
+	assert.Equal(t, int32(3), connections.Load(), "Stream should stop after 204 No Content")
+
+	// Without reconnection the channel closes when the stream ends
+	connections.Store(0)
+	events, err = client.StreamEvents(context.TODO(), axios.Config{Method: "GET", URL: server.URL}, nil)
+	assert.NoError(t, err, "Opening the stream should succeed")
+	count := 0
+	for range events {
+		count++
+	}
+	assert.Equal(t, 2, count, "Only the first connection's events should be delivered")
+	assert.Equal(t, int32(1), connections.Load(), "Stream should not reconnect")
+}
+
+// TestClientStreamEventsLineEndings verifies that lines may end in CR, LF or CRLF, and that an
+// event ending in CR is delivered without waiting for more data.
+func TestClientStreamEventsLineEndings(t *testing.T) {
+	// Mock server that mixes line endings, then holds the connection open after a CR-only event
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 1\rdata: first\rdata: line\r\r")
+		fmt.Fprint(w, "id: 2\r\ndata: second\n\r\n")
+		fmt.Fprint(w, "data: third\r\r")
+		w.(http.Flusher).Flush()
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := axios.NewClient(axios.Config{}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := client.StreamEvents(ctx, axios.Config{Method: "GET", URL: server.URL}, nil)
+	assert.NoError(t, err, "Opening the stream should succeed")
+
+	var received []axios.Event
+	for range 3 {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-ctx.Done():
+			t.Fatal("Events ending in CR should be delivered while the stream stays open")
+		}
+	}
+	assert.Equal(t, []axios.Event{
+		{ID: "1", Event: "message", Data: "first\nline"},
+		{ID: "2", Event: "message", Data: "second"},
+		{ID: "2", Event: "message", Data: "third"},
+	}, received, "Events should be parsed whatever their line endings")
+}
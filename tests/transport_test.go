@@ -0,0 +1,288 @@
+package axios_test
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClientProxyURL verifies that requests are routed through the configured proxy.
+func TestClientProxyURL(t *testing.T) {
+	// Mock proxy that answers on behalf of the target host
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied " + r.URL.String()))
+	}))
+	defer proxy.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{ProxyURL: proxy.URL})
+
+	resp, err := client.Get(context.TODO(), "http://example.invalid/resource")
+	assert.NoError(t, err, "Request should succeed through the proxy")
+	assert.Equal(t, "proxied http://example.invalid/resource", string(resp.Body), "Proxy should receive the absolute target URL")
+
+	// An invalid proxy URL surfaces as a request error
+	client = axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{ProxyURL: "://bad"})
+	_, err = client.Get(context.TODO(), "http://example.invalid/resource")
+	assert.ErrorContains(t, err, "parsing proxy URL", "Invalid proxy URL should be reported")
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// TestClientCustomTransport verifies that injected http.Clients and RoundTrippers are used with interceptors still applied.
+func TestClientCustomTransport(t *testing.T) {
+	// RoundTripper that answers without any network I/O
+	var seenAuth string
+	mock := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		seenAuth = req.Header.Get("Authorization")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("mocked")),
+			Request:    req,
+		}, nil
+	})
+
+	// Injected http.Client
+	hc := &http.Client{Transport: mock}
+	client := axios.NewClientWithHTTPClient(axios.Config{}, hc)
+	client.GetInterceptorManager().AddInterceptor(axios.BearerAuthInterceptor("token"))
+	resp, err := client.Get(context.TODO(), "http://example.invalid/")
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "mocked", string(resp.Body), "Injected client should be used")
+	assert.Equal(t, "Bearer token", seenAuth, "Interceptors should still run")
+	assert.Same(t, hc, client.HTTPClient(), "Injected http.Client should be kept")
+
+	// Replaced RoundTripper
+	client = axios.NewClient(axios.Config{Timeout: 10}, nil)
+	client.SetTransport(mock)
+	resp, err = client.Get(context.TODO(), "http://example.invalid/")
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "mocked", string(resp.Body), "Replaced transport should be used")
+}
+
+// TestClientDialTimeout verifies that the dial timeout applies to connecting independently of the request timeout.
+func TestClientDialTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// A generous dial timeout connects normally
+	client := axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{
+		DialTimeout: 5 * time.Second,
+		KeepAlive:   -1,
+	})
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "ok", string(resp.Body), "Response should be received")
+
+	// A dial timeout too short to connect fails fast despite the long request timeout
+	client = axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{DialTimeout: time.Nanosecond})
+	start := time.Now()
+	_, err = client.Get(context.TODO(), server.URL)
+	assert.True(t, axios.IsTimeout(err), "Dial should time out")
+	assert.Less(t, time.Since(start), time.Second, "Dial timeout should not wait for the request timeout")
+}
+
+// TestClientConnectionLimits verifies that per-host connection caps and the response header timeout are applied.
+func TestClientConnectionLimits(t *testing.T) {
+	// Mock server that tracks how many requests it handles at once
+	var active, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := active.Add(1)
+		defer active.Add(-1)
+		for {
+			seen := peak.Load()
+			if current <= seen || peak.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		if r.URL.Path == "/slow" {
+			time.Sleep(500 * time.Millisecond)
+		} else {
+			time.Sleep(20 * time.Millisecond)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// Concurrent requests share a single connection
+	client := axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{MaxConnsPerHost: 1})
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Get(context.TODO(), server.URL)
+			assert.NoError(t, err, "Request should succeed")
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), peak.Load(), "Only one request should be in flight per host")
+
+	// Slow response headers fail fast despite the long request timeout
+	client = axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{ResponseHeaderTimeout: 50 * time.Millisecond})
+	start := time.Now()
+	_, err := client.Get(context.TODO(), server.URL+"/slow")
+	assert.True(t, axios.IsTimeout(err), "Waiting for headers should time out")
+	assert.Less(t, time.Since(start), 400*time.Millisecond, "Header timeout should not wait for the request timeout")
+}
+
+// TestClientDisableCompression verifies that the transport only requests compressed responses when compression is enabled.
+func TestClientDisableCompression(t *testing.T) {
+	// Mock server that echoes the Accept-Encoding header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Accept-Encoding")))
+	}))
+	defer server.Close()
+
+	// By default gzip is negotiated
+	client := axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{})
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "gzip", string(resp.Body), "Transport should request gzip")
+
+	// With compression disabled no encoding is requested, whatever the buffer sizes
+	client = axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{
+		DisableCompression: true,
+		ReadBufferSize:     64 << 10,
+		WriteBufferSize:    64 << 10,
+	})
+	resp, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Empty(t, string(resp.Body), "Transport should not request compression")
+}
+
+// TestClientClose verifies that closing the client releases idle connections while it stays usable.
+func TestClientClose(t *testing.T) {
+	// Mock server that reports when connections are closed
+	closed := make(chan struct{}, 10)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed <- struct{}{}
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+	_, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+
+	// Concurrent closes release the idle keep-alive connection
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Close()
+		}()
+	}
+	wg.Wait()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Idle connection should be closed")
+	}
+
+	// The client opens a new connection afterwards
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed after Close")
+	assert.Equal(t, "ok", string(resp.Body), "Response should be received")
+}
+
+// TestClientDisableKeepAlive verifies that a request can opt out of connection reuse without affecting others.
+func TestClientDisableKeepAlive(t *testing.T) {
+	// Mock server that counts new connections
+	var conns atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strconv.FormatBool(r.Close)))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := axios.NewClient(axios.Config{Timeout: 10}, nil)
+
+	// Pooled requests reuse one connection
+	for range 2 {
+		resp, err := client.Get(context.TODO(), server.URL)
+		assert.NoError(t, err, "Request should succeed")
+		assert.Equal(t, "false", string(resp.Body), "Request should keep the connection alive")
+	}
+	assert.Equal(t, int32(1), conns.Load(), "Connection should be reused")
+
+	// A request without keep-alive closes its connection, so the next request needs a new one
+	for range 2 {
+		resp, err := client.Get(context.TODO(), server.URL, axios.Config{DisableKeepAlive: true})
+		assert.NoError(t, err, "Request should succeed")
+		assert.Equal(t, "true", string(resp.Body), "Request should ask to close the connection")
+	}
+	assert.Equal(t, int32(2), conns.Load(), "Connection should not be reused after a request without keep-alive")
+
+	// Pooling resumes for later requests
+	for range 2 {
+		_, err := client.Get(context.TODO(), server.URL)
+		assert.NoError(t, err, "Request should succeed")
+	}
+	assert.Equal(t, int32(3), conns.Load(), "Later requests should share a new pooled connection")
+}
+
+// TestClientHTTP2 verifies that HTTP/2 can be forced and tuned for servers that support it.
+func TestClientHTTP2(t *testing.T) {
+	// Mock HTTP/2 server that reports the protocol used
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	// A custom TLS configuration falls back to HTTP/1.1 unless HTTP/2 is forced
+	client := axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{RootCAs: pool})
+	resp, err := client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "HTTP/1.1", string(resp.Body), "HTTP/1.1 should be used by default with custom TLS")
+
+	client = axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{RootCAs: pool, ForceHTTP2: true})
+	resp, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "HTTP/2.0", string(resp.Body), "HTTP/2 should be negotiated when forced")
+
+	// Tuning options imply HTTP/2
+	client = axios.NewClient(axios.Config{Timeout: 10}, &axios.TransportOptions{
+		RootCAs: pool,
+		HTTP2:   &axios.HTTP2Options{ReadIdleTimeout: 30 * time.Second, StrictMaxConcurrentStreams: true},
+	})
+	resp, err = client.Get(context.TODO(), server.URL)
+	assert.NoError(t, err, "Request should succeed")
+	assert.Equal(t, "HTTP/2.0", string(resp.Body), "HTTP/2 should be negotiated with tuning options")
+}
@@ -0,0 +1,112 @@
+package axios_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	axios "github.com/MOHAMMADmiZAN/go-axios/axios"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRecorder verifies that interactions are recorded with redacted headers and replayed offline.
+func TestRecorder(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte("hello " + r.URL.Query().Get("name")))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "hello.json")
+	config := axios.Config{BaseURL: server.URL, Headers: http.Header{"Authorization": {"Bearer secret"}}}
+
+	// First run: no cassette yet, so the real server is hit and recorded
+	recorder, err := axios.NewRecorder(path, nil)
+	assert.NoError(t, err, "Recorder should be created")
+	assert.True(t, recorder.Recording(), "Recorder should record without a cassette")
+	client := axios.NewClient(config, nil)
+	client.SetTransport(recorder)
+	resp, err := client.Get(context.Background(), "/hello", axios.Config{Params: map[string]string{"name": "vcr"}})
+	assert.NoError(t, err, "Recorded request should succeed")
+	assert.Equal(t, "hello vcr", string(resp.Body), "Recorded response should be returned")
+	assert.NoError(t, recorder.Save(), "Cassette should be saved")
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err, "Cassette file should exist")
+	assert.NotContains(t, string(data), "secret", "Sensitive headers should be redacted")
+	assert.Contains(t, string(data), "REDACTED", "Redacted headers should be marked")
+
+	// Second run: the cassette is replayed without touching the server
+	server.Close()
+	recorder, err = axios.NewRecorder(path, nil)
+	assert.NoError(t, err, "Recorder should load the cassette")
+	assert.False(t, recorder.Recording(), "Recorder should replay an existing cassette")
+	client = axios.NewClient(config, nil)
+	client.SetTransport(recorder)
+	resp, err = client.Get(context.Background(), "/hello", axios.Config{Params: map[string]string{"name": "vcr"}})
+	assert.NoError(t, err, "Replayed request should succeed")
+	assert.Equal(t, "hello vcr", string(resp.Body), "Replayed response should match the recording")
+	assert.Equal(t, 1, hits, "Replay should not hit the server")
+
+	_, err = client.Get(context.Background(), "/hello", axios.Config{Params: map[string]string{"name": "vcr"}})
+	assert.ErrorIs(t, err, axios.ErrInteractionNotFound, "Each recorded interaction should be replayed once")
+
+	_, err = axios.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), &axios.RecorderOptions{Mode: axios.ModeReplay})
+	assert.Error(t, err, "Replay mode should require an existing cassette")
+}
+
+// TestRecorderBinaryBody verifies that bodies that are not valid UTF-8 survive a round trip through the cassette.
+func TestRecorderBinaryBody(t *testing.T) {
+	payload := []byte{0xff, 0x00, 0xfe, 0x10}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "binary.json")
+	recorder, _ := axios.NewRecorder(path, &axios.RecorderOptions{Mode: axios.ModeRecord})
+	client := axios.NewClient(axios.Config{}, nil)
+	client.SetTransport(recorder)
+	_, err := client.Get(context.Background(), server.URL)
+	assert.NoError(t, err, "Recorded request should succeed")
+	assert.NoError(t, recorder.Save(), "Cassette should be saved")
+
+	recorder, err = axios.NewRecorder(path, &axios.RecorderOptions{Mode: axios.ModeReplay})
+	assert.NoError(t, err, "Recorder should load the cassette")
+	client.SetTransport(recorder)
+	resp, err := client.Get(context.Background(), server.URL)
+	assert.NoError(t, err, "Replayed request should succeed")
+	assert.Equal(t, payload, resp.Body, "Binary body should be replayed byte for byte")
+}
+
+// TestRecorderLeavesRequestAlone verifies that recording sends a copy of the request, so the
+// caller's request keeps its own body.
+func TestRecorderLeavesRequestAlone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	recorder, err := axios.NewRecorder(filepath.Join(t.TempDir(), "echo.json"), &axios.RecorderOptions{Mode: axios.ModeRecord})
+	assert.NoError(t, err, "Recorder should be created")
+
+	body := io.NopCloser(strings.NewReader("payload"))
+	req, err := http.NewRequest(http.MethodPost, server.URL, body)
+	assert.NoError(t, err, "Building the request should succeed")
+
+	resp, err := recorder.RoundTrip(req)
+	assert.NoError(t, err, "Recorded round trip should succeed")
+	defer resp.Body.Close()
+	echoed, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "payload", string(echoed), "Server should receive the request body")
+	assert.Same(t, req, resp.Request, "Response should refer to the caller's request")
+	assert.Equal(t, body, req.Body, "Caller's request body should not be replaced")
+}